@@ -0,0 +1,106 @@
+package parseform
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// fieldOwners assigns each key, in top-level bracket form like "meta[deep]", to the
+// index of the struct field that claims it under WithDepthFirstConsumption. The field
+// whose form key is the longest prefix of the key wins, so form:"user[profile]"
+// claims user[profile][city] before form:"user" does. Between siblings sharing a form
+// key, a plain value goes to a scalar field and nested keys to a struct, slice or map
+// field. Keys no field claims are left out.
+func (p *Parser) fieldOwners(structType reflect.Type, keys []string) map[string]int {
+	// Resolve the candidate fields once rather than for every key
+	type candidate struct {
+		index     int
+		name      string
+		composite bool
+	}
+	var candidates []candidate
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+		tag, skip := p.parseFormTag(structType, fieldType)
+		if skip || tag.hasOption("raw") || tag.joinedKeys() != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{index: i, name: tag.name, composite: p.isCompositeType(fieldType.Type)})
+	}
+
+	owners := make(map[string]int, len(keys))
+	for _, key := range keys {
+		owner, ownerLen, ownerFits := -1, -1, false
+		for _, field := range candidates {
+			nested := strings.HasPrefix(key, field.name+"[")
+			if key != field.name && !nested {
+				continue
+			}
+
+			// A plain value fits a scalar field and nested keys fit a composite one
+			fits := nested == field.composite
+			if len(field.name) > ownerLen || (len(field.name) == ownerLen && fits && !ownerFits) {
+				owner, ownerLen, ownerFits = field.index, len(field.name), fits
+			}
+		}
+
+		if owner >= 0 {
+			owners[key] = owner
+		}
+	}
+
+	return owners
+}
+
+// ownedValues returns the values whose keys are owned by the field at index
+func (p *Parser) ownedValues(values url.Values, owners map[string]int, index int) url.Values {
+	owned := make(url.Values)
+	for key, value := range values {
+		if owner, claimed := owners[key]; claimed && owner == index {
+			owned[key] = value
+		}
+	}
+	return owned
+}
+
+// ownedNestedData returns the nested field data, keyed like "meta][deep]", whose keys
+// are owned by the field at index
+func (p *Parser) ownedNestedData(fieldData map[string]string, owners map[string]int, index int) map[string]string {
+	owned := make(map[string]string)
+	for key, value := range fieldData {
+		if owner, claimed := owners[p.topLevelKey(key)]; claimed && owner == index {
+			owned[key] = value
+		}
+	}
+	return owned
+}
+
+// nestedOwners runs fieldOwners over nested field data keys
+func (p *Parser) nestedOwners(structType reflect.Type, fieldData map[string]string) map[string]int {
+	keys := make([]string, 0, len(fieldData))
+	for key := range fieldData {
+		keys = append(keys, p.topLevelKey(key))
+	}
+	return p.fieldOwners(structType, keys)
+}
+
+// topLevelKey turns a nested key like "meta][deep]" back into bracket form, "meta[deep]"
+func (p *Parser) topLevelKey(key string) string {
+	return strings.Replace(key, "]", "", 1)
+}
+
+// isCompositeType reports whether values of t are built from nested keys
+func (p *Parser) isCompositeType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeType
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Array, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Ptr:
+		return p.isCompositeType(t.Elem())
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package parseform
+
+import (
+	"reflect"
+	"testing"
+)
+
+type consumeProfile struct {
+	City string `form:"city"`
+}
+
+type consumeForm struct {
+	User struct {
+		Name    string         `form:"name"`
+		Profile consumeProfile `form:"profile"`
+	} `form:"user"`
+	Profile consumeProfile    `form:"user[profile]"`
+	Flat    string            `form:"meta"`
+	Meta    map[string]string `form:"meta"`
+}
+
+func TestDepthFirstConsumption(t *testing.T) {
+	const formData = "user[name]=a&user[profile][city]=Rome&meta=flat&meta[deep]=nested"
+
+	var got consumeForm
+	if err := NewParser(WithDepthFirstConsumption()).ParseForm(formData, &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+
+	var want consumeForm
+	want.User.Name = "a"
+	want.Profile.City = "Rome"
+	want.Flat = "flat"
+	want.Meta = map[string]string{"deep": "nested"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseForm() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDefaultConsumptionSharesKeys(t *testing.T) {
+	// Without the option every matching field sees the key, so the overlapping
+	// profile is filled twice
+	var got consumeForm
+	if err := NewParser().ParseForm("user[profile][city]=Rome", &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if got.User.Profile.City != "Rome" || got.Profile.City != "Rome" {
+		t.Errorf("ParseForm() = %+v, want both profiles filled", got)
+	}
+}
+
+func TestDepthFirstConsumptionScalarAndStructSiblings(t *testing.T) {
+	var got struct {
+		Flat string `form:"meta"`
+		Meta struct {
+			Deep string `form:"deep"`
+		} `form:"meta"`
+	}
+	if err := NewParser(WithDepthFirstConsumption()).ParseForm("meta=flat&meta[deep]=nested", &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if got.Flat != "flat" || got.Meta.Deep != "nested" {
+		t.Errorf("ParseForm() = %+v, want {Flat:flat Meta:{Deep:nested}}", got)
+	}
+}
@@ -0,0 +1,448 @@
+package parseform
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// parseIntValue converts a form value in the given base to an int64, coercing
+// decimal values under lenient mode
+func (p *Parser) parseIntValue(value string, base int) (int64, error) {
+	intVal, err := strconv.ParseInt(p.trimBasePrefix(value, base), base, 64)
+	if err == nil || !p.lenient || base != 10 {
+		return intVal, err
+	}
+
+	if floatVal, ok := p.lenientFloat(value); ok && floatVal >= math.MinInt64 && floatVal < math.MaxInt64 {
+		return int64(floatVal), nil
+	}
+	return 0, err
+}
+
+// parseUintValue converts a form value in the given base to a uint64, coercing
+// decimal values under lenient mode
+func (p *Parser) parseUintValue(value string, base int) (uint64, error) {
+	uintVal, err := strconv.ParseUint(p.trimBasePrefix(value, base), base, 64)
+	if err == nil || !p.lenient || base != 10 {
+		return uintVal, err
+	}
+
+	if floatVal, ok := p.lenientFloat(value); ok && floatVal >= 0 && floatVal < math.MaxUint64 {
+		return uint64(floatVal), nil
+	}
+	return 0, err
+}
+
+// timeType is the reflect.Type of time.Time, which is decoded from a single value
+// rather than as a nested struct
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseTimeValue parses Unix epoch seconds, optionally with a fraction such as
+// "1699999999.250" (either "." or "," may separate it), into a UTC time with
+// nanosecond precision. Other values are parsed as RFC 3339.
+func (p *Parser) parseTimeValue(value string) (time.Time, error) {
+	seconds, fraction, hasFraction := strings.Cut(value, ".")
+	if !hasFraction {
+		seconds, fraction, hasFraction = strings.Cut(value, ",")
+	}
+
+	sec, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || strings.HasPrefix(seconds, "+") {
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	if !hasFraction {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	// Digits beyond nanoseconds are truncated
+	if fraction == "" || len(fraction) > 9 && strings.Trim(fraction[9:], "0123456789") != "" {
+		return time.Time{}, fmt.Errorf("invalid epoch fraction in %q", value)
+	}
+	if len(fraction) > 9 {
+		fraction = fraction[:9]
+	}
+	nsec, err := strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid epoch fraction in %q", value)
+	}
+
+	if strings.HasPrefix(seconds, "-") {
+		return time.Unix(sec, -int64(nsec)).UTC(), nil
+	}
+	return time.Unix(sec, int64(nsec)).UTC(), nil
+}
+
+// parseTimeLayout parses value with a layout tag like layout:"02 January 2006".
+// Month names registered with WithMonthNames are first translated to the English
+// names time.Parse expects, abbreviated when the layout uses "Jan".
+func (p *Parser) parseTimeLayout(value, layout string) (time.Time, error) {
+	if len(p.monthNames) > 0 {
+		abbreviated := strings.Contains(layout, "Jan") && !strings.Contains(layout, "January")
+		value = p.translateMonthNames(value, abbreviated)
+	}
+	return time.Parse(layout, value)
+}
+
+// translateMonthNames replaces each word of value found in the WithMonthNames table,
+// compared case-insensitively, with the English name of its month
+func (p *Parser) translateMonthNames(value string, abbreviated bool) string {
+	var b strings.Builder
+	start := -1
+	flush := func(end int) {
+		word := value[start:end]
+		if month, ok := p.monthNames[strings.ToLower(word)]; ok && month >= time.January && month <= time.December {
+			word = month.String()
+			if abbreviated {
+				word = word[:3]
+			}
+		}
+		b.WriteString(word)
+		start = -1
+	}
+	for i, r := range value {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			flush(i)
+		}
+		b.WriteRune(r)
+	}
+	if start >= 0 {
+		flush(len(value))
+	}
+	return b.String()
+}
+
+// boolWord reads value as a boolean: anything strconv.ParseBool accepts, or a word
+// registered with WithBoolValues, compared case-insensitively. The struct and dynamic
+// paths share it so they agree on what is a boolean.
+func (p *Parser) boolWord(value string) (bool, bool) {
+	if boolVal, err := strconv.ParseBool(value); err == nil {
+		return boolVal, true
+	}
+	for _, word := range p.trueValues {
+		if strings.EqualFold(value, word) {
+			return true, true
+		}
+	}
+	for _, word := range p.falseValues {
+		if strings.EqualFold(value, word) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// setScaledValue sets an integer field from a decimal value counted in minor units,
+// per its scale tag: with scale:"2", price=9.99 stores exactly 999. Extra decimal
+// places are rounded half away from zero, or rejected under WithStrictMode.
+func (p *Parser) setScaledValue(field reflect.Value, value, scale, path string) error {
+	places, err := strconv.Atoi(scale)
+	if err != nil || places < 0 || places > 18 {
+		return fmt.Errorf("invalid scale %q on %s", scale, path)
+	}
+
+	scaled, err := p.parseScaledValue(value, places)
+	switch {
+	case err != nil:
+		p.recordTypeError(field, path, value)
+	case field.CanInt() && !field.OverflowInt(scaled):
+		field.SetInt(scaled)
+	case field.CanUint() && scaled >= 0 && !field.OverflowUint(uint64(scaled)):
+		field.SetUint(uint64(scaled))
+	default:
+		p.recordTypeError(field, path, value)
+	}
+	return nil
+}
+
+// parseScaledValue converts a decimal value like "-12.345" to an integer count of
+// 10^-places units without going through floating point
+func (p *Parser) parseScaledValue(value string, places int) (int64, error) {
+	digits := value
+	negative := strings.HasPrefix(digits, "-")
+	if negative || strings.HasPrefix(digits, "+") {
+		digits = digits[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole+fraction == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid decimal %q", value)
+	}
+
+	roundUp := false
+	if len(fraction) > places {
+		if p.strict && strings.Trim(fraction[places:], "0") != "" {
+			return 0, fmt.Errorf("%q has more than %d decimal places", value, places)
+		}
+		roundUp = fraction[places] >= '5'
+		fraction = fraction[:places]
+	}
+	fraction += strings.Repeat("0", places-len(fraction))
+
+	units, err := strconv.ParseInt("0"+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if roundUp {
+		if units == math.MaxInt64 {
+			return 0, fmt.Errorf("%q is out of range", value)
+		}
+		units++
+	}
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// setInferredValue sets an interface{} field from value. Without an infer tag the
+// type is inferred like FormToMap does; infer:"bool,int,string" tries exactly those
+// types in that order, so flag=1 becomes true rather than 1. Supported types are
+// bool, int, int64, float64 and string.
+func (p *Parser) setInferredValue(field reflect.Value, value string, tag formTag, path string) error {
+	order := tag.structTag.Get("infer")
+	if order == "" {
+		field.Set(reflect.ValueOf(p.convertValueToType(value)))
+		return nil
+	}
+
+	for _, typeName := range strings.Split(order, ",") {
+		var inferred interface{}
+		switch typeName = strings.TrimSpace(typeName); typeName {
+		case "bool":
+			if boolVal, ok := p.boolWord(value); ok {
+				inferred = boolVal
+			}
+		case "int":
+			if intVal, err := strconv.Atoi(value); err == nil {
+				inferred = intVal
+			}
+		case "int64":
+			if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+				inferred = intVal
+			}
+		case "float64":
+			if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+				inferred = floatVal
+			}
+		case "string":
+			inferred = value
+		default:
+			return fmt.Errorf("unknown infer type %q on %s", typeName, path)
+		}
+
+		if inferred != nil {
+			field.Set(reflect.ValueOf(inferred))
+			return nil
+		}
+	}
+
+	p.recordTypeError(field, path, value)
+	return nil
+}
+
+// runeValue reads a value consisting of exactly one character, like ",", as a rune
+func (p *Parser) runeValue(value string) (rune, bool) {
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) || r == utf8.RuneError {
+		return 0, false
+	}
+	return r, true
+}
+
+// trimBasePrefix strips the 0x, 0o or 0b prefix matching base, keeping any sign
+func (p *Parser) trimBasePrefix(value string, base int) string {
+	prefix := map[int]string{16: "0x", 8: "0o", 2: "0b"}[base]
+	if prefix == "" {
+		return value
+	}
+
+	sign := ""
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		sign, value = value[:1], value[1:]
+	}
+	if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+		value = value[len(prefix):]
+	}
+	return sign + value
+}
+
+// parseFloatValue converts a form value to a float64, coercing it under lenient mode
+func (p *Parser) parseFloatValue(value string) (float64, error) {
+	floatVal, err := strconv.ParseFloat(value, 64)
+	if err == nil || !p.lenient {
+		return floatVal, err
+	}
+
+	if floatVal, ok := p.lenientFloat(value); ok {
+		return floatVal, nil
+	}
+	return 0, err
+}
+
+// parseBoolValue converts a form value to a bool, coercing it under lenient mode
+func (p *Parser) parseBoolValue(value string) (bool, error) {
+	boolVal, err := strconv.ParseBool(value)
+	if err == nil {
+		return boolVal, nil
+	}
+	if boolVal, ok := p.boolWord(value); ok {
+		return boolVal, nil
+	}
+	if !p.lenient {
+		return false, err
+	}
+
+	if floatVal, ok := p.lenientFloat(value); ok {
+		return floatVal != 0, nil
+	}
+	return false, err
+}
+
+// lenientFloat reads a value the way a loosely typed backend would: surrounding
+// whitespace is ignored, empty means zero and booleans mean 1 or 0
+func (p *Parser) lenientFloat(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, true
+	}
+
+	if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
+		return floatVal, true
+	}
+
+	if boolVal, err := strconv.ParseBool(value); err == nil {
+		if boolVal {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// handleEmptyNumber handles an empty value for a numeric field and reports whether
+// value was empty. The field is set to zero under WithEmptyNumberAsZero or
+// WithLenientMode and is otherwise left untouched.
+func (p *Parser) handleEmptyNumber(field reflect.Value, value string) bool {
+	if value != "" || !isNumericKind(field.Kind()) {
+		return false
+	}
+
+	if p.emptyNumberZero || p.lenient {
+		field.Set(reflect.Zero(field.Type()))
+	}
+	return true
+}
+
+// isNumericKind reports whether kind is an integer or floating-point kind
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseJSONValue decodes a JSON-encoded form value into field. A value that is
+// still percent-encoded is URL-decoded and decoded again.
+func (p *Parser) parseJSONValue(field reflect.Value, value, path string) {
+	decoded := reflect.New(field.Type())
+	err := json.Unmarshal([]byte(value), decoded.Interface())
+	if err != nil && strings.Contains(value, "%") {
+		if unescaped, unescapeErr := url.QueryUnescape(value); unescapeErr == nil {
+			decoded = reflect.New(field.Type())
+			err = json.Unmarshal([]byte(unescaped), decoded.Interface())
+		}
+	}
+
+	if err != nil {
+		p.recordTypeError(field, path, value)
+		return
+	}
+	field.Set(decoded.Elem())
+}
+
+// parseXMLValue decodes an XML fragment form value into field
+func (p *Parser) parseXMLValue(field reflect.Value, value, path string) {
+	decoded := reflect.New(field.Type())
+	if err := xml.Unmarshal([]byte(value), decoded.Interface()); err != nil {
+		p.recordTypeError(field, path, value)
+		return
+	}
+	field.Set(decoded.Elem())
+}
+
+// decodeBytes decodes the value of a []byte or [N]byte field per its encoding tag:
+// hex, base64 (padded or not) or base64url. Without the tag the value is used as-is.
+func (p *Parser) decodeBytes(value string, tag formTag, path string) ([]byte, error) {
+	var data []byte
+	var err error
+
+	encoding := tag.structTag.Get("encoding")
+	switch encoding {
+	case "":
+		return []byte(value), nil
+	case "hex":
+		data, err = hex.DecodeString(value)
+	case "base64":
+		// An unescaped "+" arrives as a space in form data
+		value = strings.ReplaceAll(value, " ", "+")
+		if strings.HasSuffix(value, "=") {
+			data, err = base64.StdEncoding.DecodeString(value)
+		} else {
+			data, err = base64.RawStdEncoding.DecodeString(value)
+		}
+	case "base64url":
+		if strings.HasSuffix(value, "=") {
+			data, err = base64.URLEncoding.DecodeString(value)
+		} else {
+			data, err = base64.RawURLEncoding.DecodeString(value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q on %s", encoding, path)
+	}
+
+	if err != nil {
+		return nil, ValidationErrors{{Field: path, Message: "is not valid " + encoding, Err: errors.Join(ErrInvalidValue, err)}}
+	}
+	return data, nil
+}
+
+// setByteArray decodes a value into a [N]byte field. The decoded length must match
+// the array size, unless the form:",pad" option allows shorter values to be zero-padded.
+func (p *Parser) setByteArray(field reflect.Value, value string, tag formTag, path string) error {
+	data, err := p.decodeBytes(value, tag, path)
+	if err != nil {
+		return err
+	}
+
+	size := field.Len()
+	if len(data) > size || (len(data) < size && !tag.hasOption("pad")) {
+		return ValidationErrors{{Field: path, Message: fmt.Sprintf("decoded length %d does not match size %d", len(data), size), Err: ErrInvalidValue}}
+	}
+
+	array := reflect.New(field.Type()).Elem()
+	reflect.Copy(array, reflect.ValueOf(data))
+	field.Set(array)
+	return nil
+}
@@ -0,0 +1,211 @@
+package parseform
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFormToMapInfersScalarsAtEveryDepth(t *testing.T) {
+	parser := NewParser(WithBoolValues([]string{"yes"}, []string{"no"}))
+
+	got, err := parser.FormToMap("flag=yes&off=no&age=25&x[flag]=yes&list[0]=no&list[1]=1.5&name=bob")
+	if err != nil {
+		t.Fatalf("FormToMap() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"flag": true,
+		"off":  false,
+		"age":  25,
+		"x":    map[string]interface{}{"flag": true},
+		"list": []interface{}{false, 1.5},
+		"name": "bob",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FormToMap() = %#v, want %#v", got, want)
+	}
+}
+
+func TestFormToMapAgreesWithStructParsing(t *testing.T) {
+	type nested struct {
+		Flag bool `form:"flag"`
+	}
+	type form struct {
+		Flag bool   `form:"flag"`
+		X    nested `form:"x"`
+		List []bool `form:"list"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		truth    []string
+		falsity  []string
+	}{
+		{"custom words", "flag=yes&x[flag]=no&list[0]=yes&list[1]=no", []string{"yes"}, []string{"no"}},
+		{"default words", "flag=true&x[flag]=false&list[0]=true&list[1]=false", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.truth != nil {
+				opts = append(opts, WithBoolValues(tt.truth, tt.falsity))
+			}
+			parser := NewParser(opts...)
+
+			var parsed form
+			if err := parser.ParseForm(tt.formData, &parsed); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			m, err := parser.FormToMap(tt.formData)
+			if err != nil {
+				t.Fatalf("FormToMap() error = %v", err)
+			}
+
+			if m["flag"] != parsed.Flag {
+				t.Errorf("flag: FormToMap = %#v, ParseForm = %v", m["flag"], parsed.Flag)
+			}
+			if x := m["x"].(map[string]interface{}); x["flag"] != parsed.X.Flag {
+				t.Errorf("x[flag]: FormToMap = %#v, ParseForm = %v", x["flag"], parsed.X.Flag)
+			}
+			list := m["list"].([]interface{})
+			if len(list) != len(parsed.List) {
+				t.Fatalf("list: FormToMap = %#v, ParseForm = %v", list, parsed.List)
+			}
+			for i := range list {
+				if list[i] != parsed.List[i] {
+					t.Errorf("list[%d]: FormToMap = %#v, ParseForm = %v", i, list[i], parsed.List[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEmptyNumbers(t *testing.T) {
+	type form struct {
+		Count int            `form:"count"`
+		Size  uint           `form:"size"`
+		Price float64        `form:"price"`
+		Nums  []int          `form:"nums"`
+		Rates []float64      `form:"rates"`
+		Caps  map[string]int `form:"caps"`
+	}
+	initial := form{Count: 1, Size: 2, Price: 3}
+	formData := "count=&size=&price=&nums[0]=&nums[1]=5&rates[0]=&rates[1]=1.5&caps[a]=&caps[b]=2"
+
+	tests := []struct {
+		name string
+		opts []Option
+		want form
+	}{
+		{
+			name: "skipped by default",
+			want: form{Count: 1, Size: 2, Price: 3, Nums: []int{0, 5}, Rates: []float64{0, 1.5}, Caps: map[string]int{"b": 2}},
+		},
+		{
+			name: "skipped under strict mode without errors",
+			opts: []Option{WithStrictMode()},
+			want: form{Count: 1, Size: 2, Price: 3, Nums: []int{0, 5}, Rates: []float64{0, 1.5}, Caps: map[string]int{"b": 2}},
+		},
+		{
+			name: "zero with WithEmptyNumberAsZero",
+			opts: []Option{WithEmptyNumberAsZero()},
+			want: form{Nums: []int{0, 5}, Rates: []float64{0, 1.5}, Caps: map[string]int{"a": 0, "b": 2}},
+		},
+		{
+			name: "zero under lenient mode",
+			opts: []Option{WithLenientMode()},
+			want: form{Nums: []int{0, 5}, Rates: []float64{0, 1.5}, Caps: map[string]int{"a": 0, "b": 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := initial
+			if err := NewParser(tt.opts...).ParseForm(formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertValueToType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  interface{}
+	}{
+		{"0", 0},
+		{"-42", -42},
+		{"9223372036854775807", inferredInt(math.MaxInt64)},
+		{"-9223372036854775808", inferredInt(math.MinInt64)},
+		{"9223372036854775808", 9223372036854775808.0},
+		{"2147483648", inferredInt(2147483648)},
+		{"1.5", 1.5},
+		{"1e3", 1000.0},
+		{"true", true},
+		{"False", false},
+		{"007", 7},
+		{"", ""},
+		{"abc", "abc"},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parser.convertValueToType(tt.value); got != tt.want {
+				t.Errorf("convertValueToType(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// inferredInt is the type convertValueToType gives an integer: int when it fits the
+// platform's int, int64 otherwise
+func inferredInt(n int64) interface{} {
+	if n >= math.MinInt && n <= math.MaxInt {
+		return int(n)
+	}
+	return n
+}
+
+// TestFormToMapTypedScalarContract states the output contract of the dynamic
+// path: top-level values and direct array items are inferred like nested ones
+// in every format built on FormToMap
+func TestFormToMapTypedScalarContract(t *testing.T) {
+	parser := NewParser()
+	formData := "age=25&price=9.5&active=true&name=John&ids[0]=7&ids[1]=x&user[age]=30"
+
+	m, err := parser.FormToMap(formData)
+	if err != nil {
+		t.Fatalf("FormToMap() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"age":    25,
+		"price":  9.5,
+		"active": true,
+		"name":   "John",
+		"ids":    []interface{}{7, "x"},
+		"user":   map[string]interface{}{"age": 30},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("FormToMap() = %#v, want %#v", m, want)
+	}
+
+	data, err := parser.FormToJSON(formData)
+	if err != nil {
+		t.Fatalf("FormToJSON() error = %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if decoded["age"] != 25.0 || decoded["ids"].([]interface{})[0] != 7.0 {
+		t.Errorf("FormToJSON() = %s, want numbers for age and ids[0]", data)
+	}
+}
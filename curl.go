@@ -0,0 +1,40 @@
+package parseform
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FormToCURL builds a curl command that reproduces a form submission to targetURL.
+// Each key-value pair becomes its own -d argument, in the order it appears in formData.
+// The method defaults to POST.
+func (p *Parser) FormToCURL(formData, targetURL, method string) (string, error) {
+	if _, err := url.ParseQuery(formData); err != nil {
+		return "", fmt.Errorf("failed to parse form data: %w", err)
+	}
+
+	if method == "" {
+		method = "POST"
+	}
+
+	parts := []string{"curl", "-X", shellQuote(strings.ToUpper(method))}
+	for _, pair := range strings.Split(formData, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(pair, "=")
+		key, _ = url.QueryUnescape(key)
+		value, _ = url.QueryUnescape(value)
+		parts = append(parts, "-d", shellQuote(url.QueryEscape(key)+"="+url.QueryEscape(value)))
+	}
+	parts = append(parts, shellQuote(targetURL))
+
+	return strings.Join(parts, " "), nil
+}
+
+// shellQuote quotes s for safe use as a single POSIX shell argument
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,83 @@
+package parseform
+
+import "testing"
+
+func TestFormToCURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		formData string
+		url      string
+		method   string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "default method",
+			formData: "name=Ann",
+			url:      "https://example.com/signup",
+			want:     `curl -X 'POST' -d 'name=Ann' 'https://example.com/signup'`,
+		},
+		{
+			name:     "multiple pairs keep their order",
+			formData: "b=2&a=1&b=3",
+			url:      "https://example.com",
+			method:   "put",
+			want:     `curl -X 'PUT' -d 'b=2' -d 'a=1' -d 'b=3' 'https://example.com'`,
+		},
+		{
+			name:     "spaces",
+			formData: "full+name=Ann%20Lee",
+			url:      "https://example.com",
+			want:     `curl -X 'POST' -d 'full+name=Ann+Lee' 'https://example.com'`,
+		},
+		{
+			name:     "single quote in value",
+			formData: "note=it%27s",
+			url:      "https://example.com",
+			want:     `curl -X 'POST' -d 'note=it%27s' 'https://example.com'`,
+		},
+		{
+			name:     "single quote in url",
+			formData: "a=1",
+			url:      "https://example.com/it's",
+			want:     `curl -X 'POST' -d 'a=1' 'https://example.com/it'\''s'`,
+		},
+		{
+			name:     "escaped ampersand stays in its value",
+			formData: "q=salt%26pepper&page=2",
+			url:      "https://example.com",
+			want:     `curl -X 'POST' -d 'q=salt%26pepper' -d 'page=2' 'https://example.com'`,
+		},
+		{
+			name:     "bracketed keys",
+			formData: "user[name]=Ann&tags[]=x",
+			url:      "https://example.com",
+			want:     `curl -X 'POST' -d 'user%5Bname%5D=Ann' -d 'tags%5B%5D=x' 'https://example.com'`,
+		},
+		{
+			name:     "empty pairs skipped",
+			formData: "&a=1&&",
+			url:      "https://example.com",
+			want:     `curl -X 'POST' -d 'a=1' 'https://example.com'`,
+		},
+		{
+			name:     "invalid form data",
+			formData: "a=%zz",
+			url:      "https://example.com",
+			wantErr:  true,
+		},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.FormToCURL(tt.formData, tt.url, tt.method)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormToCURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormToCURL() =\n  %s\nwant\n  %s", got, tt.want)
+			}
+		})
+	}
+}
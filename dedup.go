@@ -0,0 +1,60 @@
+package parseform
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// dedupSlice removes repeated elements from a slice field, keeping the first of each
+// in order. With dedup:"true" elements are compared by value; with the form key of
+// a field of struct elements, like dedup:"id", they are compared by that field.
+func (p *Parser) dedupSlice(field reflect.Value, by, path string) error {
+	if field.Len() < 2 {
+		return nil
+	}
+
+	seen := make(map[interface{}]bool, field.Len())
+	deduped := reflect.MakeSlice(field.Type(), 0, field.Len())
+	for i := 0; i < field.Len(); i++ {
+		elem := field.Index(i)
+
+		key := elem
+		if by != "true" {
+			structValue := reflect.Indirect(elem)
+			if structValue.Kind() != reflect.Struct {
+				if !structValue.IsValid() {
+					deduped = reflect.Append(deduped, elem)
+					continue
+				}
+				return fmt.Errorf("dedup key %q on %s needs struct elements", by, path)
+			}
+			keyField, found := p.fieldByFormKey(structValue, by)
+			if !found {
+				return fmt.Errorf("dedup key %q on %s is not a field of %s", by, path, structValue.Type())
+			}
+			key = keyField
+		}
+
+		id := p.dedupKey(key)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		deduped = reflect.Append(deduped, elem)
+	}
+
+	field.Set(deduped)
+	return nil
+}
+
+// dedupKey returns a map key identifying a value: the value itself when its type is
+// comparable, and its Go-syntax representation otherwise
+func (p *Parser) dedupKey(value reflect.Value) interface{} {
+	if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Type().Comparable() {
+		return value.Interface()
+	}
+	return fmt.Sprintf("%#v", value.Interface())
+}
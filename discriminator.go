@@ -0,0 +1,61 @@
+package parseform
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// typeDiscriminator selects a concrete type for an interface field from the value of one of its keys
+type typeDiscriminator struct {
+	key   string
+	types map[string]reflect.Type
+}
+
+// parseDiscriminated fills an interface field with the concrete type its registered
+// discriminator selects, reporting false when no discriminator is registered for path
+func (p *Parser) parseDiscriminated(field reflect.Value, fieldData map[string]string, path string) (bool, error) {
+	discriminator, registered := p.discriminators[path]
+	if !registered {
+		return false, nil
+	}
+
+	return true, p.decodeDiscriminated(field, fieldData, path, discriminator)
+}
+
+// decodeDiscriminated fills an interface value with the concrete type selected by the discriminator
+func (p *Parser) decodeDiscriminated(field reflect.Value, fieldData map[string]string, path string, discriminator typeDiscriminator) error {
+	typeName, exists := fieldData[discriminator.key+"]"]
+	if !exists {
+		return ValidationErrors{{Field: path, Message: fmt.Sprintf("missing discriminator %s", discriminator.key), Err: ErrDiscriminator}}
+	}
+
+	concreteType, known := discriminator.types[typeName]
+	if !known {
+		return ValidationErrors{{Field: path, Message: fmt.Sprintf("unknown %s %q", discriminator.key, typeName), Err: ErrDiscriminator}}
+	}
+	if !concreteType.AssignableTo(field.Type()) {
+		return fmt.Errorf("type %s registered for %s %q is not assignable to %s", concreteType, discriminator.key, typeName, field.Type())
+	}
+
+	// Allocate the concrete value, following one level of pointer indirection
+	structType := concreteType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("type %s registered for %s %q is not a struct", concreteType, discriminator.key, typeName)
+	}
+
+	newValue := reflect.New(structType)
+	var errs ValidationErrors
+	if err := collectFieldErrors(&errs, p.parseStructFromMap(fieldData, newValue.Elem(), path)); err != nil {
+		return err
+	}
+
+	if concreteType.Kind() == reflect.Ptr {
+		field.Set(newValue)
+	} else {
+		field.Set(newValue.Elem())
+	}
+	return errs.errOrNil()
+}
@@ -0,0 +1,99 @@
+package parseform
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type emailEvent struct {
+	To string `form:"to"`
+}
+
+type smsEvent struct {
+	Phone string `form:"phone"`
+}
+
+func TestWithTypeDiscriminator(t *testing.T) {
+	types := map[string]reflect.Type{
+		"email": reflect.TypeOf(emailEvent{}),
+		"sms":   reflect.TypeOf(&smsEvent{}),
+	}
+	type form struct {
+		Event  interface{}   `form:"event"`
+		Events []interface{} `form:"events"`
+	}
+	parser := NewParser(WithTypeDiscriminator("event", "type", types), WithTypeDiscriminator("events", "kind", types))
+
+	// Changing the caller's map afterwards does not affect the parser
+	delete(types, "email")
+
+	tests := []struct {
+		name     string
+		formData string
+		want     form
+		wantErr  error
+	}{
+		{
+			name:     "value type",
+			formData: "event[type]=email&event[to]=a@b.c",
+			want:     form{Event: emailEvent{To: "a@b.c"}},
+		},
+		{
+			name:     "pointer type",
+			formData: "event[type]=sms&event[phone]=123",
+			want:     form{Event: &smsEvent{Phone: "123"}},
+		},
+		{
+			name:     "slice elements",
+			formData: "events[0][kind]=sms&events[0][phone]=1&events[1][kind]=email&events[1][to]=x",
+			want:     form{Events: []interface{}{&smsEvent{Phone: "1"}, emailEvent{To: "x"}}},
+		},
+		{
+			name:     "unknown type",
+			formData: "event[type]=fax",
+			wantErr:  ErrDiscriminator,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := parser.ParseForm(tt.formData, &got)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseForm() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTypeDiscriminatorConcurrentUse(t *testing.T) {
+	parser := NewParser(WithTypeDiscriminator("event", "type", map[string]reflect.Type{
+		"email": reflect.TypeOf(emailEvent{}),
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got struct {
+				Event interface{} `form:"event"`
+			}
+			if err := parser.ParseForm("event[type]=email&event[to]=a@b.c", &got); err != nil {
+				t.Errorf("ParseForm() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,254 @@
+package parseform
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stringerType is the reflect.Type of fmt.Stringer
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// EncodeForm encodes a struct, or a pointer to one, as form-urlencoded data using
+// the same form tags ParseForm reads. Nested structs, slices and maps use bracket
+// notation, like user[tags][0]=a, and map keys are sorted. A value whose type
+// implements fmt.Stringer is encoded with its String method, which suits enums and
+// custom ID types; time.Time values are encoded with their layout tag, or as RFC 3339
+// with nanoseconds. Integers tagged scale are written as decimals. Nil pointers and
+// false presence booleans are omitted, as are raw, readonly and joined (form:"a+b")
+// fields and zero-valued omitempty fields.
+//
+// The result parses back into an equal value, with two exceptions. A fmt.Stringer
+// value only parses back when it is an integer enum whose labels are registered
+// with WithEnum. A time.Time keeps only what its layout records: without a layout
+// tag it parses back as the same instant, as time.Time.Equal reports, but loses its
+// monotonic clock reading and location.
+func (p *Parser) EncodeForm(source interface{}) (string, error) {
+	value := reflect.ValueOf(source)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", errors.New("source must not be nil")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return "", fmt.Errorf("source must be a struct or a pointer to one, got %T", source)
+	}
+
+	fb := NewFormBuilder()
+	if err := p.encodeStruct(fb, value, ""); err != nil {
+		return "", err
+	}
+	return fb.Encode(), nil
+}
+
+// encodeStruct adds the fields of a struct under prefix, or at the top level when
+// prefix is empty
+func (p *Parser) encodeStruct(fb *FormBuilder, structValue reflect.Value, prefix string) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structValue.NumField(); i++ {
+		tag, skip := p.parseFormTag(structType, structType.Field(i))
+		field := structValue.Field(i)
+		if skip || tag.hasOption("raw") || tag.hasOption("readonly") || tag.joinedKeys() != nil ||
+			tag.hasOption("omitempty") && field.IsZero() {
+			continue
+		}
+
+		key := tag.name
+		if prefix != "" {
+			key = prefix + "[" + tag.name + "]"
+		}
+		if err := p.encodeValue(fb, field, key, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeValue adds the form values of a single value under key
+func (p *Parser) encodeValue(fb *FormBuilder, value reflect.Value, key string, tag formTag) error {
+	// Values decoded from a single encoded value are encoded the same way
+	if tag.structTag.Get("nested") == "json" || tag.hasOption("xml") {
+		return p.encodeMarshaled(fb, value, key, tag)
+	}
+
+	if value.Type() == timeType {
+		layout := tag.structTag.Get("layout")
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		fb.Add(key, value.Interface().(time.Time).Format(layout))
+		return nil
+	}
+	if scale, scaled := tag.structTag.Lookup("scale"); scaled && (value.CanInt() || value.CanUint()) {
+		return p.encodeScaled(fb, value, key, scale)
+	}
+	if text, ok := p.stringerValue(value); ok {
+		fb.Add(key, text)
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return p.encodeValue(fb, value.Elem(), key, tag)
+
+	case reflect.String:
+		fb.Add(key, value.String())
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fb.Add(key, strconv.FormatInt(value.Int(), tag.numberBase()))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fb.Add(key, strconv.FormatUint(value.Uint(), tag.numberBase()))
+
+	case reflect.Float32, reflect.Float64:
+		fb.Add(key, strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()))
+
+	case reflect.Bool:
+		if tag.presence() {
+			// Presence booleans are true whenever the key is sent, so false is omitted
+			if value.Bool() {
+				fb.Add(key, "true")
+			}
+		} else if tag.hasOption("int_bool") {
+			// Legacy backends tagged int_bool expect 1 and 0, which parsing already accepts
+			fb.Add(key, map[bool]string{true: "1", false: "0"}[value.Bool()])
+		} else {
+			fb.Add(key, strconv.FormatBool(value.Bool()))
+		}
+
+	case reflect.Struct:
+		return p.encodeStruct(fb, value, key)
+
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return p.encodeBytes(fb, value, key, tag)
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := p.encodeValue(fb, value.Index(i), fmt.Sprintf("%s[%d]", key, i), tag); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		entries := make(map[string]reflect.Value, value.Len())
+		mapKeys := make([]string, 0, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			mapKey := fmt.Sprint(iter.Key().Interface())
+			entries[mapKey] = iter.Value()
+			mapKeys = append(mapKeys, mapKey)
+		}
+		sort.Strings(mapKeys)
+
+		for _, mapKey := range mapKeys {
+			if err := p.encodeValue(fb, entries[mapKey], key+"["+mapKey+"]", tag); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("cannot encode %s of type %s", key, value.Type())
+	}
+	return nil
+}
+
+// encodeScaled adds an integer tagged scale:"2", held in minor units, as the decimal
+// amount it was parsed from: 999 is written as 9.99
+func (p *Parser) encodeScaled(fb *FormBuilder, value reflect.Value, key, scale string) error {
+	places, err := strconv.Atoi(scale)
+	if err != nil || places < 0 || places > 18 {
+		return fmt.Errorf("invalid scale %q on %s", scale, key)
+	}
+
+	var digits string
+	negative := false
+	if value.CanInt() {
+		units := value.Int()
+		negative = units < 0
+		if negative {
+			digits = strconv.FormatUint(-uint64(units), 10)
+		} else {
+			digits = strconv.FormatUint(uint64(units), 10)
+		}
+	} else {
+		digits = strconv.FormatUint(value.Uint(), 10)
+	}
+
+	if places > 0 {
+		if len(digits) <= places {
+			digits = strings.Repeat("0", places-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if negative {
+		digits = "-" + digits
+	}
+	fb.Add(key, digits)
+	return nil
+}
+
+// stringerValue returns the String result of a value implementing fmt.Stringer,
+// through a pointer receiver when the value is addressable
+func (p *Parser) stringerValue(value reflect.Value) (string, bool) {
+	if !value.CanInterface() || (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		return "", false
+	}
+
+	if value.Type().Implements(stringerType) {
+		return value.Interface().(fmt.Stringer).String(), true
+	}
+	if value.CanAddr() && value.Addr().Type().Implements(stringerType) {
+		return value.Addr().Interface().(fmt.Stringer).String(), true
+	}
+	return "", false
+}
+
+// encodeBytes adds a []byte or [N]byte value in the encoding named by its encoding tag
+func (p *Parser) encodeBytes(fb *FormBuilder, value reflect.Value, key string, tag formTag) error {
+	data := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(data), value)
+
+	switch encoding := tag.structTag.Get("encoding"); encoding {
+	case "":
+		fb.Add(key, string(data))
+	case "hex":
+		fb.Add(key, hex.EncodeToString(data))
+	case "base64":
+		fb.Add(key, base64.StdEncoding.EncodeToString(data))
+	case "base64url":
+		fb.Add(key, base64.URLEncoding.EncodeToString(data))
+	default:
+		return fmt.Errorf("unsupported encoding %q on %s", encoding, key)
+	}
+	return nil
+}
+
+// encodeMarshaled adds a value tagged nested:"json" or xml as a single JSON or XML value
+func (p *Parser) encodeMarshaled(fb *FormBuilder, value reflect.Value, key string, tag formTag) error {
+	var data []byte
+	var err error
+	if tag.hasOption("xml") {
+		data, err = xml.Marshal(value.Interface())
+	} else {
+		data, err = json.Marshal(value.Interface())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	fb.Add(key, string(data))
+	return nil
+}
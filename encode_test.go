@@ -0,0 +1,160 @@
+package parseform
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// roundTrip encodes source with EncodeForm and parses the result into a new value
+// of the same type
+func roundTrip[T any](t *testing.T, source T) (T, string) {
+	t.Helper()
+	parser := NewParser()
+	encoded, err := parser.EncodeForm(source)
+	if err != nil {
+		t.Fatalf("EncodeForm() error = %v", err)
+	}
+	var parsed T
+	if err := parser.ParseForm(encoded, &parsed); err != nil {
+		t.Fatalf("ParseForm(%q) error = %v", encoded, err)
+	}
+	return parsed, encoded
+}
+
+func TestEncodeFormRoundTripPresence(t *testing.T) {
+	type form struct {
+		Active    bool `form:"active" presence:"true"`
+		Subscribe bool `form:"subscribe" presence:"true"`
+	}
+
+	source := form{Active: false, Subscribe: true}
+	parsed, encoded := roundTrip(t, source)
+	if encoded != "subscribe=true" {
+		t.Errorf("EncodeForm() = %q, want %q", encoded, "subscribe=true")
+	}
+	if parsed != source {
+		t.Errorf("round trip = %+v, want %+v", parsed, source)
+	}
+}
+
+func TestEncodeFormRoundTripScale(t *testing.T) {
+	type form struct {
+		Price    int64  `form:"price" scale:"2"`
+		Refund   int    `form:"refund" scale:"2"`
+		Cents    int    `form:"cents" scale:"2"`
+		Quantity uint32 `form:"quantity" scale:"3"`
+		Whole    int    `form:"whole" scale:"0"`
+	}
+
+	source := form{Price: 999, Refund: -1050, Cents: 5, Quantity: 1500, Whole: 7}
+	parsed, encoded := roundTrip(t, source)
+	want := "price=9.99&refund=-10.50&cents=0.05&quantity=1.500&whole=7"
+	if encoded != want {
+		t.Errorf("EncodeForm() = %q, want %q", encoded, want)
+	}
+	if parsed != source {
+		t.Errorf("round trip = %+v, want %+v", parsed, source)
+	}
+}
+
+func TestEncodeFormRoundTripLayout(t *testing.T) {
+	type form struct {
+		Day     time.Time `form:"day" layout:"2006-01-02"`
+		Created time.Time `form:"created"`
+	}
+
+	source := form{
+		Day:     time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC),
+		Created: time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC),
+	}
+	parsed, encoded := roundTrip(t, source)
+	if want := "day=2024-03-15&created=2024-03-15T10%3A30%3A00Z"; encoded != want {
+		t.Errorf("EncodeForm() = %q, want %q", encoded, want)
+	}
+	if !reflect.DeepEqual(parsed, source) {
+		t.Errorf("round trip = %+v, want %+v", parsed, source)
+	}
+}
+
+type encodeStatus int
+
+func (s encodeStatus) String() string {
+	return map[encodeStatus]string{1: "open", 2: "closed"}[s]
+}
+
+func TestEncodeFormRoundTrip(t *testing.T) {
+	type address struct {
+		City string `form:"city"`
+		Zip  string `form:"zip"`
+	}
+	type form struct {
+		Name     string            `form:"name"`
+		Age      int8              `form:"age"`
+		Score    float64           `form:"score"`
+		Active   bool              `form:"active"`
+		Legacy   bool              `form:"legacy,int_bool"`
+		Mask     uint16            `form:"mask,format=hex"`
+		Tags     []string          `form:"tags"`
+		Home     address           `form:"home"`
+		Previous []address         `form:"previous"`
+		Work     *address          `form:"work"`
+		Labels   map[string]string `form:"labels"`
+		Status   encodeStatus      `form:"status"`
+		Note     string            `form:"note"`
+	}
+
+	source := form{
+		Name:     "Ann & Co = 100%",
+		Age:      -12,
+		Score:    3.25,
+		Active:   true,
+		Legacy:   true,
+		Mask:     0xbeef,
+		Tags:     []string{"a", "b c"},
+		Home:     address{City: "Oslo", Zip: "0150"},
+		Previous: []address{{City: "Rome"}, {City: "Lima", Zip: "15001"}},
+		Work:     &address{City: "Bergen"},
+		Labels:   map[string]string{"x": "1", "y": "two"},
+		Status:   2,
+	}
+
+	parser := NewParser(WithEnum(reflect.TypeOf(encodeStatus(0)), map[string]int64{"open": 1, "closed": 2}))
+	encoded, err := parser.EncodeForm(source)
+	if err != nil {
+		t.Fatalf("EncodeForm() error = %v", err)
+	}
+	var parsed form
+	if err := parser.ParseForm(encoded, &parsed); err != nil {
+		t.Fatalf("ParseForm(%q) error = %v", encoded, err)
+	}
+	if !reflect.DeepEqual(parsed, source) {
+		t.Errorf("round trip of %q = %+v, want %+v", encoded, parsed, source)
+	}
+}
+
+func TestEncodeFormRoundTripLimits(t *testing.T) {
+	t.Run("stringer without enum labels", func(t *testing.T) {
+		type form struct {
+			Status encodeStatus `form:"status"`
+		}
+		parsed, encoded := roundTrip(t, form{Status: 2})
+		if encoded != "status=closed" || parsed.Status != 0 {
+			t.Errorf("round trip of %q = %+v, want the label left unparsed", encoded, parsed)
+		}
+	})
+
+	t.Run("time without layout keeps the instant", func(t *testing.T) {
+		type form struct {
+			At time.Time `form:"at"`
+		}
+		source := form{At: time.Now().In(time.FixedZone("X", 3*3600))}
+		parsed, encoded := roundTrip(t, source)
+		if !parsed.At.Equal(source.At) {
+			t.Errorf("round trip of %q = %v, want the instant %v", encoded, parsed.At, source.At)
+		}
+		if parsed.At == source.At {
+			t.Errorf("round trip of %q kept the monotonic reading", encoded)
+		}
+	})
+}
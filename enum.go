@@ -0,0 +1,9 @@
+package parseform
+
+import "reflect"
+
+// enumValue looks up the value of a label registered for enumType
+func (p *Parser) enumValue(enumType reflect.Type, label string) (int64, bool) {
+	value, known := p.enums[enumType][label]
+	return value, known
+}
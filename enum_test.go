@@ -0,0 +1,93 @@
+package parseform
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type statusID int
+
+type priority uint8
+
+func TestWithEnum(t *testing.T) {
+	type form struct {
+		Status   statusID   `form:"status"`
+		Priority priority   `form:"priority"`
+		History  []statusID `form:"history"`
+	}
+	labels := map[string]int64{"open": 142, "closed": 143}
+	parser := NewParser(
+		WithEnum(reflect.TypeOf(statusID(0)), labels),
+		WithEnum(reflect.TypeOf(priority(0)), map[string]int64{"high": 1, "low": 9}),
+	)
+
+	// Changing the caller's map afterwards does not affect the parser
+	labels["open"] = 0
+
+	tests := []struct {
+		name     string
+		formData string
+		want     form
+	}{
+		{"labels", "status=open&priority=low", form{Status: 142, Priority: 9}},
+		{"numbers win over labels", "status=143&priority=2", form{Status: 143, Priority: 2}},
+		{"slice elements", "history[0]=closed&history[1]=142", form{History: []statusID{143, 142}}},
+		{"unknown labels are skipped", "status=pending", form{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := parser.ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithEnumStrictMode(t *testing.T) {
+	var got struct {
+		Status statusID `form:"status"`
+	}
+	err := NewParser(WithStrictMode(), WithEnum(reflect.TypeOf(statusID(0)), map[string]int64{"open": 142})).ParseForm("status=pending", &got)
+
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Field != "status" {
+		t.Fatalf("ParseForm() error = %v, want a status error", err)
+	}
+}
+
+func TestWithEnumIgnoresNonIntegerTypes(t *testing.T) {
+	var got struct {
+		Name string `form:"name"`
+	}
+	if err := NewParser(WithEnum(reflect.TypeOf(""), map[string]int64{"a": 1})).ParseForm("name=a", &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if got.Name != "a" {
+		t.Errorf("Name = %q, want the label kept as text", got.Name)
+	}
+}
+
+func TestWithEnumConcurrentUse(t *testing.T) {
+	parser := NewParser(WithEnum(reflect.TypeOf(statusID(0)), map[string]int64{"open": 142}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got struct {
+				Status statusID `form:"status"`
+			}
+			if err := parser.ParseForm("status=open", &got); err != nil || got.Status != 142 {
+				t.Errorf("ParseForm() = %v, %v, want 142", got.Status, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,114 @@
+package parseform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NewParserFromEnv creates a parser configured from PARSEFORM_* environment variables,
+// followed by any explicit options, which take precedence. Unset variables keep the
+// defaults. The recognized variables are:
+//
+//	PARSEFORM_KEYED_SLICES          bool  WithKeyedSlices (default false)
+//	PARSEFORM_DOTTED_KEYS           bool  WithDottedKeys (default false)
+//	PARSEFORM_JSON_POINTER_KEYS     bool  WithJSONPointerKeys (default false)
+//	PARSEFORM_REQUIRE_ALL_FIELDS    bool  WithRequireAllFields (default false)
+//	PARSEFORM_SKIP_READONLY         bool  WithSkipReadonly (default false)
+//	PARSEFORM_STRICT                bool  WithStrictMode (default false)
+//	PARSEFORM_UNKNOWN_KEYS_ERROR    bool  WithUnknownNestedKeysError (default false)
+//	PARSEFORM_LENIENT               bool  WithLenientMode (default false)
+//	PARSEFORM_EMPTY_NUMBER_AS_ZERO  bool  WithEmptyNumberAsZero (default false)
+//	PARSEFORM_MAX_BODY_SIZE         int   WithMaxBodySize in bytes (default 10 MiB)
+//	PARSEFORM_MAX_SLICE_LENGTH      int   WithMaxSliceLength (default 0, no cap)
+//	PARSEFORM_INDEX_OVERFLOW        ignore|error|grow  WithIndexOverflowPolicy (default ignore)
+//	PARSEFORM_REPEATED_KEYS         first|last|error   WithRepeatedKeyPolicy (default first)
+//	PARSEFORM_UNESCAPE_MODE         query|path         WithUnescapeMode (default query)
+func NewParserFromEnv(opts ...Option) (*Parser, error) {
+	var envOpts []Option
+
+	flags := []struct {
+		name   string
+		option func() Option
+	}{
+		{"PARSEFORM_KEYED_SLICES", WithKeyedSlices},
+		{"PARSEFORM_DOTTED_KEYS", WithDottedKeys},
+		{"PARSEFORM_JSON_POINTER_KEYS", WithJSONPointerKeys},
+		{"PARSEFORM_REQUIRE_ALL_FIELDS", WithRequireAllFields},
+		{"PARSEFORM_SKIP_READONLY", WithSkipReadonly},
+		{"PARSEFORM_STRICT", WithStrictMode},
+		{"PARSEFORM_UNKNOWN_KEYS_ERROR", WithUnknownNestedKeysError},
+		{"PARSEFORM_LENIENT", WithLenientMode},
+		{"PARSEFORM_EMPTY_NUMBER_AS_ZERO", WithEmptyNumberAsZero},
+	}
+	for _, flag := range flags {
+		value, set := os.LookupEnv(flag.name)
+		if !set {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", flag.name, err)
+		}
+		if enabled {
+			envOpts = append(envOpts, flag.option())
+		}
+	}
+
+	if value, set := os.LookupEnv("PARSEFORM_MAX_BODY_SIZE"); set {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PARSEFORM_MAX_BODY_SIZE: %w", err)
+		}
+		envOpts = append(envOpts, WithMaxBodySize(size))
+	}
+
+	if value, set := os.LookupEnv("PARSEFORM_MAX_SLICE_LENGTH"); set {
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PARSEFORM_MAX_SLICE_LENGTH: %w", err)
+		}
+		envOpts = append(envOpts, WithMaxSliceLength(length))
+	}
+
+	if value, set := os.LookupEnv("PARSEFORM_INDEX_OVERFLOW"); set {
+		policies := map[string]IndexOverflowPolicy{
+			"ignore": IndexOverflowIgnore,
+			"error":  IndexOverflowError,
+			"grow":   IndexOverflowGrow,
+		}
+		policy, known := policies[strings.ToLower(value)]
+		if !known {
+			return nil, fmt.Errorf("invalid PARSEFORM_INDEX_OVERFLOW: %q", value)
+		}
+		envOpts = append(envOpts, WithIndexOverflowPolicy(policy))
+	}
+
+	if value, set := os.LookupEnv("PARSEFORM_REPEATED_KEYS"); set {
+		policies := map[string]RepeatedKeyPolicy{
+			"first": RepeatedKeyFirst,
+			"last":  RepeatedKeyLast,
+			"error": RepeatedKeyError,
+		}
+		policy, known := policies[strings.ToLower(value)]
+		if !known {
+			return nil, fmt.Errorf("invalid PARSEFORM_REPEATED_KEYS: %q", value)
+		}
+		envOpts = append(envOpts, WithRepeatedKeyPolicy(policy))
+	}
+
+	if value, set := os.LookupEnv("PARSEFORM_UNESCAPE_MODE"); set {
+		modes := map[string]UnescapeMode{
+			"query": UnescapeQuery,
+			"path":  UnescapePath,
+		}
+		mode, known := modes[strings.ToLower(value)]
+		if !known {
+			return nil, fmt.Errorf("invalid PARSEFORM_UNESCAPE_MODE: %q", value)
+		}
+		envOpts = append(envOpts, WithUnescapeMode(mode))
+	}
+
+	return NewParser(append(envOpts, opts...)...), nil
+}
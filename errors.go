@@ -0,0 +1,85 @@
+package parseform
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel errors carried by FieldError.Err, for matching with errors.Is or WithIgnoreErrors
+var (
+	// ErrRequired is reported for a field that is required but missing
+	ErrRequired = errors.New("required field missing")
+	// ErrReadOnly is reported for a value submitted for a read-only field
+	ErrReadOnly = errors.New("read-only field submitted")
+	// ErrInvalidValue is reported for a value that cannot be decoded into its field
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrConstraint is reported for a value that violates a validation tag option
+	ErrConstraint = errors.New("constraint violated")
+	// ErrIndexOverflow is reported for an index beyond an array's length or the slice cap
+	ErrIndexOverflow = errors.New("index overflow")
+	// ErrOneOf is reported for a oneof group without exactly one member present
+	ErrOneOf = errors.New("oneof violated")
+	// ErrDiscriminator is reported for a missing or unknown type discriminator
+	ErrDiscriminator = errors.New("invalid discriminator")
+	// ErrRepeatedKey is reported for a key sent more than once under RepeatedKeyError
+	ErrRepeatedKey = errors.New("repeated key")
+	// ErrUnknownKey is reported for a key no field reads under WithUnknownNestedKeysError
+	ErrUnknownKey = errors.New("unknown key")
+)
+
+// FieldError describes a problem with a single form field or field group
+type FieldError struct {
+	Field   string
+	Message string
+	Err     error
+}
+
+// Error implements the error interface
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap returns the underlying error, if any
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors aggregates every field error found while parsing a struct
+type ValidationErrors []FieldError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual field errors, so errors.Is and errors.As can match
+// sentinels such as ErrRequired anywhere in the aggregate
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fieldErr := range e {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+// errOrNil returns e as an error, or nil when it holds no field errors
+func (e ValidationErrors) errOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// collectFieldErrors appends any field errors in err to errs and returns other errors unchanged
+func collectFieldErrors(errs *ValidationErrors, err error) error {
+	var fieldErrs ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		*errs = append(*errs, fieldErrs...)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package parseform
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FormBuilder assembles form-urlencoded data using bracket notation keys
+type FormBuilder struct {
+	values url.Values
+	keys   []string
+}
+
+// NewFormBuilder creates an empty form builder
+func NewFormBuilder() *FormBuilder {
+	return &FormBuilder{values: make(url.Values)}
+}
+
+// Set sets key to value, replacing any existing values
+func (fb *FormBuilder) Set(key, value string) *FormBuilder {
+	if _, exists := fb.values[key]; !exists {
+		fb.keys = append(fb.keys, key)
+	}
+	fb.values.Set(key, value)
+	return fb
+}
+
+// Add appends value to key, keeping any existing values
+func (fb *FormBuilder) Add(key, value string) *FormBuilder {
+	if _, exists := fb.values[key]; !exists {
+		fb.keys = append(fb.keys, key)
+	}
+	fb.values.Add(key, value)
+	return fb
+}
+
+// SetNested sets a field of an indexed element, e.g. SetNested("leads[status]", 0, "name", "Alice")
+// sets leads[status][0][name]=Alice
+func (fb *FormBuilder) SetNested(base string, index int, field, value string) *FormBuilder {
+	return fb.Set(fmt.Sprintf("%s[%d][%s]", base, index, field), value)
+}
+
+// SetObject sets a field of an object, e.g. SetObject("account", "id", "1") sets account[id]=1
+func (fb *FormBuilder) SetObject(base, field, value string) *FormBuilder {
+	return fb.Set(fmt.Sprintf("%s[%s]", base, field), value)
+}
+
+// Encode returns the percent-encoded form data with keys in insertion order
+func (fb *FormBuilder) Encode() string {
+	var parts []string
+	for _, key := range fb.keys {
+		for _, value := range fb.values[key] {
+			parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// String returns the encoded form data
+func (fb *FormBuilder) String() string {
+	return fb.Encode()
+}
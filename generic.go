@@ -0,0 +1,34 @@
+package parseform
+
+// ParseForm parses form-urlencoded data into a new value of type T using a default parser.
+// The target is allocated internally, so callers never pass an untyped pointer.
+// Go type constraints cannot express "struct kinds only", so a non-struct T is
+// still reported as an error at runtime.
+func ParseForm[T any](formData string) (T, error) {
+	var target T
+	if err := NewParser().ParseForm(formData, &target); err != nil {
+		return target, err
+	}
+	return target, nil
+}
+
+// ParseFormOrDefault parses form-urlencoded data into a new T using a default parser,
+// returning defaultVal instead when parsing fails for any reason. Use it where a
+// fallback value is preferable to handling the error.
+func ParseFormOrDefault[T any](formData string, defaultVal T) T {
+	target, err := ParseForm[T](formData)
+	if err != nil {
+		return defaultVal
+	}
+	return target
+}
+
+// ParseFormTable parses spreadsheet-style form data, where each row is addressed as
+// row[i][column], into a new T per row using a default parser and appends the rows
+// to result in index order. Columns map to T's fields by their form tags, so
+// row[0][name]=Alice&row[0][age]=30&row[1][name]=Bob&row[1][age]=25 yields two rows.
+// Missing indices produce zero-valued rows. Use Parser.ParseFormTable to parse with
+// a configured parser.
+func ParseFormTable[T any](formData string, result *[]T) error {
+	return NewParser().ParseFormTable(formData, result)
+}
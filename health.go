@@ -0,0 +1,34 @@
+package parseform
+
+import "fmt"
+
+// healthCheckPayload is the well-known form HealthCheck parses
+const healthCheckPayload = "test=1&nested[key]=val"
+
+// HealthCheck parses a small well-known payload with a default parser and reports
+// a descriptive error if the result is not the expected one. Call it at startup to
+// surface library problems early; use Parser.HealthCheck to check a configured parser.
+func HealthCheck() error {
+	return NewParser().HealthCheck()
+}
+
+// HealthCheck parses a small well-known payload, test=1&nested[key]=val, with this
+// parser's options and reports a descriptive error if parsing fails or the result is
+// not the expected one, such as when options break plain nested keys.
+func (p *Parser) HealthCheck() error {
+	var probe struct {
+		Test   int `form:"test"`
+		Nested struct {
+			Key string `form:"key"`
+		} `form:"nested"`
+	}
+
+	if err := p.ParseForm(healthCheckPayload, &probe); err != nil {
+		return fmt.Errorf("parseform health check: parsing %q failed: %w", healthCheckPayload, err)
+	}
+	if probe.Test != 1 || probe.Nested.Key != "val" {
+		return fmt.Errorf("parseform health check: parsing %q gave test=%d, nested[key]=%q; want test=1, nested[key]=\"val\"",
+			healthCheckPayload, probe.Test, probe.Nested.Key)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package parseform
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Inspection describes the shape of form data without decoding it into anything
+type Inspection struct {
+	// TotalKeys counts distinct keys
+	TotalKeys int
+	// DuplicateKeys maps each key sent more than once to its number of values
+	DuplicateKeys map[string]int
+	// MaxDepth is the most bracket segments in a single key: 0 for name,
+	// 2 for user[profile] or items[0][id]
+	MaxDepth int
+	// TopLevelArray reports whether every base key is an array index, as in
+	// 0[name]=a&1[name]=b, rather than a named property
+	TopLevelArray bool
+}
+
+// Inspect reports the number of keys, repeated keys, nesting depth and top-level
+// shape of form data, to help understand an unfamiliar payload before writing a
+// struct for it. Keys are read the way FormToMap reads them; nothing is populated.
+func (p *Parser) Inspect(formData string) (Inspection, error) {
+	values, err := url.ParseQuery(formData)
+	if err != nil {
+		return Inspection{}, fmt.Errorf("failed to parse form data: %w", err)
+	}
+	if p.jsonPointerKeys {
+		values = p.rewriteKeys(values, p.jsonPointerKey)
+	}
+
+	inspection := Inspection{
+		TotalKeys:     len(values),
+		DuplicateKeys: make(map[string]int),
+		TopLevelArray: len(values) > 0,
+	}
+
+	for key, keyValues := range values {
+		if len(keyValues) > 1 {
+			inspection.DuplicateKeys[key] = len(keyValues)
+		}
+
+		parsed := p.parseKeyStructure(key)
+		depth := len(parsed.path)
+		if parsed.isArray {
+			depth++
+		}
+		if depth > inspection.MaxDepth {
+			inspection.MaxDepth = depth
+		}
+
+		if _, ok := p.arrayIndex(parsed.baseKey); !ok {
+			inspection.TopLevelArray = false
+		}
+	}
+
+	return inspection, nil
+}
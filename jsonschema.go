@@ -0,0 +1,238 @@
+package parseform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect SchemaFor declares
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of JSON Schema that SchemaFor emits
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Minimum              json.Number            `json:"minimum,omitempty"`
+	Maximum              json.Number            `json:"maximum,omitempty"`
+	ExclusiveMinimum     json.Number            `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     json.Number            `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+}
+
+// SchemaFor describes the form contract of a struct, given as a value or pointer, as
+// a JSON Schema document. Each form key becomes a property typed after its field, with
+// nested structs, slices and maps as nested objects and arrays. Required fields (and
+// every top-level field under WithRequireAllFields) are listed as required, and the
+// positive, negative, min, max, minExclusive, maxExclusive, minLen, maxLen and pattern
+// tags become the matching keywords. Fields of an integer type with WithEnum labels
+// accept an integer or one of the labels, described with anyOf and enum. Read-only
+// fields are marked readOnly. Raw and joined (form:"a+b") fields are left out, as
+// they have no single form key.
+func (p *Parser) SchemaFor(v interface{}) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema source must be a struct or a pointer to one, got %T", v)
+	}
+
+	schema, err := p.structSchema(t, true, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	schema.Schema = jsonSchemaDraft
+	schema.Title = t.Name()
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// structSchema describes the fields of a struct type. seen guards against recursive
+// types, which are described as plain objects where they recur.
+func (p *Parser) structSchema(structType reflect.Type, topLevel bool, seen map[reflect.Type]bool) (*jsonSchema, error) {
+	schema := &jsonSchema{Type: "object"}
+	if seen[structType] {
+		return schema, nil
+	}
+	seen[structType] = true
+	defer delete(seen, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		tag, skip := p.parseFormTag(structType, structType.Field(i))
+		if skip || tag.hasOption("raw") || tag.joinedKeys() != nil {
+			continue
+		}
+
+		property, err := p.typeSchema(structType.Field(i).Type, tag, seen)
+		if err != nil {
+			return nil, err
+		}
+		if err := applySchemaConstraints(property, structType.Field(i).Type, tag); err != nil {
+			return nil, err
+		}
+
+		readonly := tag.hasOption("readonly")
+		property.ReadOnly = readonly
+		if !readonly && (tag.required() || topLevel && p.requireAllFields) {
+			schema.Required = append(schema.Required, tag.name)
+		}
+
+		if schema.Properties == nil {
+			schema.Properties = make(map[string]*jsonSchema)
+		}
+		schema.Properties[tag.name] = property
+	}
+	return schema, nil
+}
+
+// typeSchema describes the values a field of type t accepts
+func (p *Parser) typeSchema(t reflect.Type, tag formTag, seen map[reflect.Type]bool) (*jsonSchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, scaled := tag.structTag.Lookup("scale"); scaled {
+			return &jsonSchema{Type: "number"}, nil
+		}
+		if labels := p.enumLabels(t, false); labels != nil {
+			return &jsonSchema{AnyOf: []*jsonSchema{{Type: "integer"}, {Type: "string", Enum: labels}}}, nil
+		}
+		return &jsonSchema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, scaled := tag.structTag.Lookup("scale"); scaled {
+			return &jsonSchema{Type: "number", Minimum: "0"}, nil
+		}
+		if labels := p.enumLabels(t, true); labels != nil {
+			return &jsonSchema{Minimum: "0", AnyOf: []*jsonSchema{{Type: "integer"}, {Type: "string", Enum: labels}}}, nil
+		}
+		return &jsonSchema{Type: "integer", Minimum: "0"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}, nil
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Struct:
+		if t == timeType {
+			return &jsonSchema{Type: "string", Format: "date-time"}, nil
+		}
+		return p.structSchema(t, false, seen)
+	case reflect.Slice, reflect.Array:
+		// Byte slices and arrays are sent as a single, optionally encoded, value
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string"}, nil
+		}
+		items, err := p.typeSchema(t.Elem(), formTag{}, seen)
+		if err != nil {
+			return nil, err
+		}
+		schema := &jsonSchema{Type: "array", Items: items}
+		if t.Kind() == reflect.Array {
+			length := t.Len()
+			schema.MaxItems = &length
+		}
+		return schema, nil
+	case reflect.Map:
+		values, err := p.typeSchema(t.Elem(), formTag{}, seen)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	}
+
+	// Interfaces and other kinds accept any value
+	return &jsonSchema{}, nil
+}
+
+// enumLabels returns the sorted WithEnum labels of an integer type, leaving out those
+// with negative values for unsigned types as the parser does, or nil when it has none
+func (p *Parser) enumLabels(t reflect.Type, unsigned bool) []string {
+	var labels []string
+	for label, value := range p.enums[t] {
+		if !unsigned || value >= 0 {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// applySchemaConstraints adds the keywords of a field's validation tags to its schema,
+// rejecting bounds the parser would reject too
+func applySchemaConstraints(schema *jsonSchema, t reflect.Type, tag formTag) error {
+	if isNumericKind(t.Kind()) {
+		if tag.hasOption("positive") {
+			schema.ExclusiveMinimum = "0"
+		}
+		if tag.hasOption("negative") {
+			schema.ExclusiveMaximum = "0"
+		}
+		for _, bound := range []struct {
+			tag     string
+			keyword *json.Number
+		}{
+			{"min", &schema.Minimum},
+			{"max", &schema.Maximum},
+			{"minExclusive", &schema.ExclusiveMinimum},
+			{"maxExclusive", &schema.ExclusiveMaximum},
+		} {
+			limit, ok := tag.structTag.Lookup(bound.tag)
+			if !ok {
+				continue
+			}
+			if _, err := strconv.ParseFloat(limit, 64); err != nil {
+				return fmt.Errorf("invalid %s bound on %s: %w", bound.tag, tag.name, err)
+			}
+			*bound.keyword = json.Number(limit)
+		}
+	}
+
+	if t.Kind() == reflect.String || t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		minKeyword, maxKeyword := &schema.MinItems, &schema.MaxItems
+		if t.Kind() == reflect.String {
+			minKeyword, maxKeyword = &schema.MinLength, &schema.MaxLength
+		}
+		for _, bound := range []struct {
+			tag     string
+			keyword **int
+		}{
+			{"minLen", minKeyword},
+			{"maxLen", maxKeyword},
+		} {
+			limit, ok := tag.structTag.Lookup(bound.tag)
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid %s on %s: %q", bound.tag, tag.name, limit)
+			}
+			*bound.keyword = &n
+		}
+	}
+
+	if pattern := tag.structTag.Get("pattern"); pattern != "" && t.Kind() == reflect.String {
+		if _, err := compilePattern(pattern); err != nil {
+			return fmt.Errorf("invalid pattern on %s: %w", tag.name, err)
+		}
+		schema.Pattern = pattern
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package parseform
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type schemaStatus int
+
+type schemaNode struct {
+	Name     string       `form:"name" required:"true"`
+	Children []schemaNode `form:"children"`
+	Parent   *schemaNode  `form:"parent"`
+}
+
+type schemaLead struct {
+	ID      int               `form:"id,readonly" required:"true"`
+	Email   string            `form:"email" required:"true" pattern:"^[^@]+@[^@]+$" maxLen:"254"`
+	Age     uint8             `form:"age" max:"120"`
+	Price   float64           `form:"price,positive" maxExclusive:"1000"`
+	Status  schemaStatus      `form:"status"`
+	Created time.Time         `form:"created"`
+	Tags    []string          `form:"tags" minLen:"1" maxLen:"5"`
+	Pair    [2]int            `form:"pair"`
+	Avatar  []byte            `form:"avatar"`
+	Labels  map[string]string `form:"labels"`
+	Address struct {
+		City string `form:"city" required:"true"`
+		Zip  string `form:"zip"`
+	} `form:"address"`
+	Items []struct {
+		SKU string `form:"sku" required:"true"`
+		Qty int    `form:"qty" min:"1"`
+	} `form:"items"`
+	Tree  schemaNode `form:"tree"`
+	Raw   string     `form:"raw,raw"`
+	Name  string     `form:"first+last"`
+	Draft bool       `form:"draft"`
+}
+
+func schemaOf(t *testing.T, p *Parser, v interface{}) *jsonSchema {
+	t.Helper()
+	data, err := p.SchemaFor(v)
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("SchemaFor() produced invalid JSON: %v", err)
+	}
+	return &schema
+}
+
+func TestSchemaFor(t *testing.T) {
+	p := NewParser(WithEnum(reflect.TypeOf(schemaStatus(0)), map[string]int64{"open": 1, "closed": 2}))
+	schema := schemaOf(t, p, &schemaLead{})
+	props := schema.Properties
+	one, five, maxEmail := 1, 5, 254
+	two := 2
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"dialect", schema.Schema, jsonSchemaDraft},
+		{"title", schema.Title, "schemaLead"},
+		{"top-level required", schema.Required, []string{"email"}},
+		{"read-only field", props["id"].ReadOnly, true},
+		{"pattern", props["email"].Pattern, "^[^@]+@[^@]+$"},
+		{"string maxLen", props["email"].MaxLength, &maxEmail},
+		{"uint type", props["age"].Type, "integer"},
+		{"uint minimum", props["age"].Minimum, json.Number("0")},
+		{"max", props["age"].Maximum, json.Number("120")},
+		{"positive", props["price"].ExclusiveMinimum, json.Number("0")},
+		{"maxExclusive", props["price"].ExclusiveMaximum, json.Number("1000")},
+		{"float type", props["price"].Type, "number"},
+		{"enum integer branch", props["status"].AnyOf[0].Type, "integer"},
+		{"enum labels", props["status"].AnyOf[1].Enum, []string{"closed", "open"}},
+		{"time format", props["created"].Format, "date-time"},
+		{"time type", props["created"].Type, "string"},
+		{"slice items", props["tags"].Items.Type, "string"},
+		{"slice minLen", props["tags"].MinItems, &one},
+		{"slice maxLen", props["tags"].MaxItems, &five},
+		{"array length", props["pair"].MaxItems, &two},
+		{"byte slice", props["avatar"].Type, "string"},
+		{"map values", props["labels"].AdditionalProperties.Type, "string"},
+		{"nested required", props["address"].Required, []string{"city"}},
+		{"nested property", props["address"].Properties["zip"].Type, "string"},
+		{"slice of structs", props["items"].Items.Required, []string{"sku"}},
+		{"slice of structs min", props["items"].Items.Properties["qty"].Minimum, json.Number("1")},
+		{"recursive slice stops", props["tree"].Properties["children"].Items.Properties, map[string]*jsonSchema(nil)},
+		{"recursive pointer stops", props["tree"].Properties["parent"].Type, "object"},
+		{"raw field left out", props["raw"], (*jsonSchema)(nil)},
+		{"joined field left out", props["first+last"], (*jsonSchema)(nil)},
+		{"bool", props["draft"].Type, "boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaForRequireAllFields(t *testing.T) {
+	type form struct {
+		ID      int    `form:"id,readonly"`
+		Name    string `form:"name"`
+		Address struct {
+			City string `form:"city"`
+		} `form:"address"`
+	}
+
+	schema := schemaOf(t, NewParser(WithRequireAllFields()), form{})
+	if want := []string{"name", "address"}; !reflect.DeepEqual(schema.Required, want) {
+		t.Errorf("Required = %v, want %v", schema.Required, want)
+	}
+	if schema.Properties["address"].Required != nil {
+		t.Errorf("nested Required = %v, want none", schema.Properties["address"].Required)
+	}
+}
+
+func TestSchemaForErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       interface{}
+		wantErr string
+	}{
+		{"not a struct", 42, "must be a struct"},
+		{"nil", nil, "must be a struct"},
+		{"bad bound", struct {
+			Age int `form:"age" min:"ten"`
+		}{}, "invalid min bound on age"},
+		{"bad length", struct {
+			Name string `form:"name" maxLen:"-1"`
+		}{}, "invalid maxLen on name"},
+		{"bad pattern", struct {
+			Code string `form:"code" pattern:"[A-Z"`
+		}{}, "invalid pattern on code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().SchemaFor(tt.v)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SchemaFor() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package parseform
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"time"
+)
+
+// ParseFormMetrics records statistics about a single struct parse
+type ParseFormMetrics struct {
+	// FieldsProcessed counts struct fields, at any depth, that received form data
+	FieldsProcessed int
+	// FieldsSkipped counts struct fields that had no matching form data
+	FieldsSkipped int
+	// TypeErrors counts values that could not be converted to their field's type
+	TypeErrors int
+	// Duration is the total time spent parsing
+	Duration time.Duration
+}
+
+// recordField counts a struct field as processed or skipped when collecting metrics
+func (p *Parser) recordField(processed bool) {
+	if p.metrics == nil {
+		return
+	}
+	if processed {
+		p.metrics.FieldsProcessed++
+	} else {
+		p.metrics.FieldsSkipped++
+	}
+}
+
+// recordTypeError counts a failed value conversion when collecting metrics. Under
+// ParseFormLenient the field is reset to its zero value and the failure is reported
+// as a warning; under WithStrictMode it is reported as a field error.
+func (p *Parser) recordTypeError(field reflect.Value, path, value string) {
+	if p.trace != nil {
+		p.failedPaths = append(p.failedPaths, path)
+	}
+	if p.metrics != nil {
+		p.metrics.TypeErrors++
+	}
+	if p.warnings != nil {
+		field.Set(reflect.Zero(field.Type()))
+		*p.warnings = append(*p.warnings, FieldError{
+			Field:   path,
+			Message: fmt.Sprintf("cannot convert %q to %s", value, field.Type()),
+		})
+	} else if p.typeErrors != nil {
+		*p.typeErrors = append(*p.typeErrors, FieldError{
+			Field:   path,
+			Message: fmt.Sprintf("cannot convert %q to %s", value, field.Type()),
+			Err:     ErrInvalidValue,
+		})
+	}
+}
+
+// typeErrorCount returns the number of type errors collected so far under WithStrictMode
+func (p *Parser) typeErrorCount() int {
+	if p.typeErrors == nil {
+		return 0
+	}
+	return len(*p.typeErrors)
+}
+
+// RunParseBenchmark parses formData iterations times, each into a fresh value of
+// target's type, and returns the average duration and heap allocations per parse.
+// target is parsed once up front to validate the input and is left populated.
+func (p *Parser) RunParseBenchmark(formData string, target interface{}, iterations int) (time.Duration, uint64, error) {
+	if iterations <= 0 {
+		return 0, 0, fmt.Errorf("iterations must be positive, got %d", iterations)
+	}
+
+	// Warm up and surface parse errors before measuring
+	if err := p.ParseForm(formData, target); err != nil {
+		return 0, 0, err
+	}
+	targetType := reflect.TypeOf(target).Elem()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if err := p.ParseForm(formData, reflect.New(targetType).Interface()); err != nil {
+			return 0, 0, err
+		}
+	}
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&after)
+
+	return elapsed / time.Duration(iterations), (after.Mallocs - before.Mallocs) / uint64(iterations), nil
+}
@@ -0,0 +1,122 @@
+package parseform
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+)
+
+// FormToMessagePack converts form data to MessagePack with the same structure
+// FormToMap produces. Map keys are written in sorted order, so equal forms encode to
+// equal bytes. The encoder is built in to keep the package free of dependencies.
+func (p *Parser) FormToMessagePack(formData string) ([]byte, error) {
+	values, err := url.ParseQuery(formData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse form data: %w", err)
+	}
+
+	result, err := p.parseFormFlexibly(values)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := appendMessagePack(nil, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to MessagePack: %w", err)
+	}
+	return data, nil
+}
+
+// appendMessagePack appends the MessagePack encoding of a value from the dynamic
+// form structure to buf
+func appendMessagePack(buf []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(buf, 0xc0), nil
+	case bool:
+		if v {
+			return append(buf, 0xc3), nil
+		}
+		return append(buf, 0xc2), nil
+	case int:
+		return appendMessagePackInt(buf, int64(v)), nil
+	case int64:
+		return appendMessagePackInt(buf, v), nil
+	case float64:
+		buf = append(buf, 0xcb)
+		return binary.BigEndian.AppendUint64(buf, math.Float64bits(v)), nil
+	case string:
+		buf = appendMessagePackHeader(buf, len(v), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		return append(buf, v...), nil
+	case []interface{}:
+		buf = appendMessagePackHeader(buf, len(v), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, elem := range v {
+			var err error
+			if buf, err = appendMessagePack(buf, elem); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf = appendMessagePackHeader(buf, len(v), 0x80, 16, 0, 0xde, 0xdf)
+		for _, key := range keys {
+			var err error
+			if buf, err = appendMessagePack(buf, key); err != nil {
+				return nil, err
+			}
+			if buf, err = appendMessagePack(buf, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", value)
+}
+
+// appendMessagePackInt appends an integer in its shortest MessagePack form
+func appendMessagePackInt(buf []byte, v int64) []byte {
+	switch {
+	case v >= 0 && v <= math.MaxInt8:
+		return append(buf, byte(v))
+	case v < 0 && v >= -32:
+		return append(buf, byte(v))
+	case v >= 0 && v <= math.MaxUint8:
+		return append(buf, 0xcc, byte(v))
+	case v >= 0 && v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xcd), uint16(v))
+	case v >= 0 && v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xce), uint32(v))
+	case v >= 0:
+		return binary.BigEndian.AppendUint64(append(buf, 0xcf), uint64(v))
+	case v >= math.MinInt8:
+		return append(buf, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buf, 0xd1), uint16(v))
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buf, 0xd2), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, 0xd3), uint64(v))
+}
+
+// appendMessagePackHeader appends the header of a string, array or map of length n:
+// the fix format (fix|n) below fixLimit, otherwise the 8-, 16- or 32-bit length
+// format. Arrays and maps have no 8-bit format, marked by a zero code.
+func appendMessagePackHeader(buf []byte, n int, fix byte, fixLimit int, code8, code16, code32 byte) []byte {
+	switch {
+	case n < fixLimit:
+		return append(buf, fix|byte(n))
+	case code8 != 0 && n <= math.MaxUint8:
+		return append(buf, code8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, code16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(buf, code32), uint32(n))
+}
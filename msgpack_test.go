@@ -0,0 +1,102 @@
+package parseform
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAppendMessagePackWidths(t *testing.T) {
+	mapOf := func(n int) map[string]interface{} {
+		m := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			m[strconv.Itoa(i)] = nil
+		}
+		return m
+	}
+	sliceOf := func(n int) []interface{} {
+		return make([]interface{}, n)
+	}
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		header string // hex encoding of the leading bytes
+		length int    // total encoded length
+	}{
+		{"nil", nil, "c0", 1},
+		{"false", false, "c2", 1},
+		{"true", true, "c3", 1},
+		{"float64", 1.5, "cb3ff8000000000000", 9},
+
+		{"positive fixint zero", 0, "00", 1},
+		{"positive fixint max", 127, "7f", 1},
+		{"uint8 min", 128, "cc80", 2},
+		{"uint8 max", 255, "ccff", 2},
+		{"uint16 min", 256, "cd0100", 3},
+		{"uint16 max", 65535, "cdffff", 3},
+		{"uint32 min", 65536, "ce00010000", 5},
+		{"uint32 max", int64(math.MaxUint32), "ceffffffff", 5},
+		{"uint64 min", int64(math.MaxUint32) + 1, "cf0000000100000000", 9},
+		{"negative fixint max", -1, "ff", 1},
+		{"negative fixint min", -32, "e0", 1},
+		{"int8 max", -33, "d0df", 2},
+		{"int8 min", -128, "d080", 2},
+		{"int16 max", -129, "d1ff7f", 3},
+		{"int16 min", -32768, "d18000", 3},
+		{"int32 max", -32769, "d2ffff7fff", 5},
+		{"int32 min", int64(math.MinInt32), "d280000000", 5},
+		{"int64 max", int64(math.MinInt32) - 1, "d3ffffffff7fffffff", 9},
+
+		{"empty fixstr", "", "a0", 1},
+		{"fixstr max", strings.Repeat("x", 31), "bf", 32},
+		{"str8 min", strings.Repeat("x", 32), "d920", 34},
+		{"str8 max", strings.Repeat("x", 255), "d9ff", 257},
+		{"str16 min", strings.Repeat("x", 256), "da0100", 259},
+		{"str16 max", strings.Repeat("x", 65535), "daffff", 65538},
+		{"str32 min", strings.Repeat("x", 65536), "db00010000", 65541},
+
+		{"empty fixarray", sliceOf(0), "90", 1},
+		{"fixarray max", sliceOf(15), "9f", 16},
+		{"array16 min", sliceOf(16), "dc0010", 19},
+
+		{"empty fixmap", mapOf(0), "80", 1},
+		// keys "0" to "9" take 2 bytes, longer ones 3, and each nil value 1
+		{"fixmap max", mapOf(15), "8f", 1 + 10*2 + 5*3 + 15},
+		{"map16 min", mapOf(16), "de0010", 3 + 10*2 + 6*3 + 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := appendMessagePack(nil, tt.value)
+			if err != nil {
+				t.Fatalf("appendMessagePack() error = %v", err)
+			}
+			header, _ := hex.DecodeString(tt.header)
+			if !bytes.HasPrefix(got, header) {
+				t.Errorf("appendMessagePack() starts % x, want % x", got[:min(len(got), len(header))], header)
+			}
+			if len(got) != tt.length {
+				t.Errorf("appendMessagePack() length = %d, want %d", len(got), tt.length)
+			}
+		})
+	}
+}
+
+func TestFormToMessagePackGolden(t *testing.T) {
+	got, err := NewParser().FormToMessagePack("name=Ann&age=30&tags[]=a&tags[]=b")
+	if err != nil {
+		t.Fatalf("FormToMessagePack() error = %v", err)
+	}
+	// {"age": 30, "name": "Ann", "tags": ["a", "b"]} with keys sorted
+	want := "83" +
+		"a3616765" + "1e" +
+		"a46e616d65" + "a3416e6e" +
+		"a474616773" + "92" + "a161" + "a162"
+	if hex.EncodeToString(got) != want {
+		t.Errorf("FormToMessagePack() = %x, want %s", got, want)
+	}
+}
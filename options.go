@@ -0,0 +1,346 @@
+package parseform
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Option configures optional Parser behavior
+type Option func(*Parser)
+
+// WithKeyedSlices lets slice fields accept collections keyed by non-numeric
+// identifiers, such as items[a1b2]=x&items[c3d4]=y. The key values are ignored:
+// keyed elements are appended after any numerically indexed elements, in the
+// order their keys first appear in the form data. When the original form data
+// is not available the keys are ordered lexically.
+func WithKeyedSlices() Option {
+	return func(p *Parser) {
+		p.keyedSlices = true
+	}
+}
+
+// WithDottedKeys lets struct parsing accept dotted keys such as user.profile.name,
+// treating them like user[profile][name]. It is opt-in because some producers
+// send keys that contain literal dots.
+func WithDottedKeys() Option {
+	return func(p *Parser) {
+		p.dottedKeys = true
+	}
+}
+
+// WithJSONPointerKeys accepts RFC 6901 JSON Pointer keys such as /leads/status/0/name
+// as equivalent to leads[status][0][name], for both struct and dynamic parsing.
+func WithJSONPointerKeys() Option {
+	return func(p *Parser) {
+		p.jsonPointerKeys = true
+	}
+}
+
+// WithRequireAllFields makes struct parsing fail when any top-level field receives
+// no value from the form. The error lists every missing form key. Unexported
+// fields and fields tagged form:"-" are exempt.
+func WithRequireAllFields() Option {
+	return func(p *Parser) {
+		p.requireAllFields = true
+	}
+}
+
+// WithSkipReadonly silently ignores form values for fields tagged form:",readonly"
+// instead of reporting them as field errors
+func WithSkipReadonly() Option {
+	return func(p *Parser) {
+		p.skipReadonly = true
+	}
+}
+
+// WithMerge parses onto the existing contents of the target instead of replacing
+// them: nested structs are updated field by field, slices and arrays are merged by
+// index (keyed elements append after the existing ones) and maps keep their entries
+func WithMerge() Option {
+	return func(p *Parser) {
+		p.merge = true
+	}
+}
+
+// WithIgnoreErrors suppresses field errors that match any of errTypes with errors.Is,
+// such as ErrConstraint or ErrInvalidValue. A field whose value produced a suppressed
+// error is reset to its zero value; when the error belongs to one element or nested
+// field, like items[5] of an array, only that part is reset and its valid siblings
+// are kept. The rest of the parse continues as usual.
+func WithIgnoreErrors(errTypes ...error) Option {
+	return func(p *Parser) {
+		p.ignoredErrors = append(p.ignoredErrors, errTypes...)
+	}
+}
+
+// WithArrayDelimiter splits a single value for a slice or array field on delim, so
+// tags=a,b,c fills three elements. A field's sep tag takes precedence, and sep:""
+// keeps that field's value as a single element.
+func WithArrayDelimiter(delim string) Option {
+	return func(p *Parser) {
+		p.arrayDelimiter = delim
+	}
+}
+
+// WithValidator plugs in the validator ParseFormAndValidate runs after parsing
+func WithValidator(v Validator) Option {
+	return func(p *Parser) {
+		p.validator = v
+	}
+}
+
+// WithStrictMode reports values that cannot be converted to their field's type, such
+// as age=abc or an unknown enum label, as field errors (ErrInvalidValue) instead of
+// skipping them silently. ParseFormLenient still reports them as warnings.
+func WithStrictMode() Option {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// WithDepthFirstConsumption gives every key to exactly one struct field: the field
+// whose form key is the longest prefix of the key, so form:"user[profile]" takes
+// user[profile][city] from form:"user". Sibling fields sharing a form key split it,
+// with meta=flat going to a scalar field and meta[deep]=nested to a struct or map
+// field. By default every matching field sees the key, so both profiles above are
+// filled and a scalar beside a map may take a nested value.
+func WithDepthFirstConsumption() Option {
+	return func(p *Parser) {
+		p.depthFirst = true
+	}
+}
+
+// WithUnknownNestedKeysError reports every key that no struct field reads, at any
+// depth, as a field error (ErrUnknownKey) named by its full bracket path, like
+// account[unexpected]. It catches schema drift in nested objects as well as at the
+// top level.
+func WithUnknownNestedKeysError() Option {
+	return func(p *Parser) {
+		p.unknownKeysError = true
+	}
+}
+
+// WithVersionedMapping renames incoming keys by payload version before struct
+// parsing. The version is read from versionKey, like api_version=2, and mappings
+// holds a map per version from incoming keys to the form keys the struct uses:
+// {"2": {"full_name": "name"}} parses full_name=Al into a form:"name" field for
+// version 2 payloads. A key's base name, like cust in cust[id], is renamed when the
+// whole key is not mapped. Payloads without a version, or with an unmapped one,
+// are parsed as they are.
+func WithVersionedMapping(versionKey string, mappings map[string]map[string]string) Option {
+	return func(p *Parser) {
+		p.versionKey = versionKey
+		p.versionMappings = mappings
+	}
+}
+
+// WithBoolValues adds words accepted as booleans, compared case-insensitively, on top
+// of those strconv.ParseBool understands: WithBoolValues([]string{"yes", "on"},
+// []string{"no", "off"}). Struct fields and the dynamic FormToMap/FormToJSON output
+// use the same vocabulary.
+func WithBoolValues(trueValues, falseValues []string) Option {
+	return func(p *Parser) {
+		p.trueValues = append(p.trueValues, trueValues...)
+		p.falseValues = append(p.falseValues, falseValues...)
+	}
+}
+
+// WithTransform registers a named transform that fields apply to their raw values,
+// in order, before conversion: with WithTransform("trim", strings.TrimSpace) and
+// WithTransform("lower", strings.ToLower), a field tagged transform:"trim,lower"
+// receives " Bob@Example.com " as "bob@example.com". A field naming a transform
+// that is not registered fails to parse.
+func WithTransform(name string, fn func(string) string) Option {
+	return func(p *Parser) {
+		if p.transforms == nil {
+			p.transforms = make(map[string]func(string) string)
+		}
+		p.transforms[name] = fn
+	}
+}
+
+// WithMonthNames translates localized month names before time.Time fields with a
+// layout tag are parsed, since the time package only knows English names:
+// WithMonthNames(map[string]time.Month{"januar": time.January, "märz": time.March})
+// lets layout:"02 January 2006" read "15 März 2024". Names are compared
+// case-insensitively, and abbreviations like "mär" are translated to the short
+// English names when the layout uses Jan.
+func WithMonthNames(names map[string]time.Month) Option {
+	return func(p *Parser) {
+		if p.monthNames == nil {
+			p.monthNames = make(map[string]time.Month)
+		}
+		for name, month := range names {
+			p.monthNames[strings.ToLower(name)] = month
+		}
+	}
+}
+
+// WithByteLength makes the minLen and maxLen tags count the bytes of string fields
+// instead of their runes, so "héllo" has length 6 rather than 5. Use it when the limit
+// protects storage sized in bytes.
+func WithByteLength() Option {
+	return func(p *Parser) {
+		p.byteLength = true
+	}
+}
+
+// WithNullMarker treats array elements sent as marker, like items[1]=__null__, as
+// intentional nulls: pointer, interface, slice and map elements are left nil, and
+// FormToMap and FormToJSON produce null. Other element types cannot hold nil, so such
+// elements are reported as field errors (ErrInvalidValue) unless WithNullAsZero is set.
+func WithNullMarker(marker string) Option {
+	return func(p *Parser) {
+		p.nullMarker = marker
+	}
+}
+
+// WithNullAsZero sets elements that WithNullMarker marks as null to their zero value
+// when their type cannot hold nil, like the ints of a []int, instead of reporting them
+func WithNullAsZero() Option {
+	return func(p *Parser) {
+		p.nullAsZero = true
+	}
+}
+
+// WithLenientMode enables best-effort type coercion for values that do not parse
+// strictly, like a loosely typed PHP backend: "true" becomes 1 for numeric fields,
+// "3.14" is truncated to 3 for integer fields, numbers become booleans (non-zero
+// is true) and empty values become zero.
+func WithLenientMode() Option {
+	return func(p *Parser) {
+		p.lenient = true
+	}
+}
+
+// WithEmptyNumberAsZero sets numeric fields to zero when their value is empty,
+// as in price=. By default an empty numeric value is skipped and the field keeps
+// its current value, so price= and a missing price behave the same; an empty map
+// value adds no entry, and an empty slice element stays zero.
+func WithEmptyNumberAsZero() Option {
+	return func(p *Parser) {
+		p.emptyNumberZero = true
+	}
+}
+
+// WithMaxBodySize caps the bytes read from request bodies and produced by
+// decompression. It defaults to 10 MiB.
+func WithMaxBodySize(n int64) Option {
+	return func(p *Parser) {
+		p.maxBodySize = n
+	}
+}
+
+// IndexOverflowPolicy decides what happens to an explicit index beyond a
+// destination's capacity, like items[4] for a [4]T array field
+type IndexOverflowPolicy int
+
+const (
+	// IndexOverflowIgnore drops elements whose index does not fit
+	IndexOverflowIgnore IndexOverflowPolicy = iota
+	// IndexOverflowError reports each overflowing index as a field error
+	IndexOverflowError
+	// IndexOverflowGrow lets slices grow past WithMaxSliceLength. Fixed-size
+	// arrays cannot grow, so overflowing elements are dropped.
+	IndexOverflowGrow
+)
+
+// WithIndexOverflowPolicy sets how indices beyond a fixed-size array's length, or
+// beyond the WithMaxSliceLength cap, are handled. The default is IndexOverflowIgnore.
+func WithIndexOverflowPolicy(policy IndexOverflowPolicy) Option {
+	return func(p *Parser) {
+		p.indexOverflow = policy
+	}
+}
+
+// RepeatedKeyPolicy decides which value a key sent more than once takes, like
+// items[0][a]=1&items[0][a]=2. Different keys that share an index, like
+// items[0][a]=1&items[0][b]=2, are not repeated and always merge into one element.
+// A plain key repeated for a top-level slice or array field, like nums=1&nums=2,
+// fills it with every value under RepeatedKeyFirst and RepeatedKeyLast.
+type RepeatedKeyPolicy int
+
+const (
+	// RepeatedKeyFirst keeps the first value of a repeated key
+	RepeatedKeyFirst RepeatedKeyPolicy = iota
+	// RepeatedKeyLast keeps the last value of a repeated key
+	RepeatedKeyLast
+	// RepeatedKeyError reports each repeated key as a field error
+	RepeatedKeyError
+)
+
+// WithRepeatedKeyPolicy sets how keys sent more than once are handled, in both
+// struct and dynamic parsing. The default is RepeatedKeyFirst.
+func WithRepeatedKeyPolicy(policy RepeatedKeyPolicy) Option {
+	return func(p *Parser) {
+		p.repeatedKeys = policy
+	}
+}
+
+// WithMaxSliceLength caps the length of slice fields; indices at or beyond n are
+// handled by the index overflow policy. Zero means no cap.
+func WithMaxSliceLength(n int) Option {
+	return func(p *Parser) {
+		p.maxSliceLength = n
+	}
+}
+
+// UnescapeMode selects how the encoded entry points URL-decode their input
+type UnescapeMode int
+
+const (
+	// UnescapeQuery decodes with url.QueryUnescape, turning "+" into a space
+	UnescapeQuery UnescapeMode = iota
+	// UnescapePath decodes with url.PathUnescape, keeping "+" as a literal plus
+	UnescapePath
+)
+
+// WithUnescapeMode sets the URL decoding used by FormToJSONEncoded and FormToMapEncoded.
+// Unicode escapes are replaced before URL decoding, so \u002B yields a "+" that
+// UnescapeQuery then turns into a space while UnescapePath keeps it as "+".
+func WithUnescapeMode(mode UnescapeMode) Option {
+	return func(p *Parser) {
+		p.unescapeMode = mode
+	}
+}
+
+// WithTypeDiscriminator lets an interface{} field at fieldPath (bracket notation,
+// like "event" or "event[payload]") decode into a concrete type chosen by the value
+// of its discriminatorKey sub-key. For event[type]=email&event[to]=a@b.c, the path
+// "event" with key "type" and {"email": reflect.TypeOf(EmailEvent{})} fills an
+// EmailEvent. On a []interface{} field each element is resolved separately, and a
+// pointer-to-interface field is allocated and pointed at the decoded value. The
+// type map is copied, and the parser is safe to share once built.
+func WithTypeDiscriminator(fieldPath, discriminatorKey string, typeMap map[string]reflect.Type) Option {
+	types := make(map[string]reflect.Type, len(typeMap))
+	for name, t := range typeMap {
+		types[name] = t
+	}
+	return func(p *Parser) {
+		if p.discriminators == nil {
+			p.discriminators = make(map[string]typeDiscriminator)
+		}
+		p.discriminators[fieldPath] = typeDiscriminator{key: discriminatorKey, types: types}
+	}
+}
+
+// WithEnum registers labels for an integer enum type, so a field of that type
+// accepts either its numeric value or a label. For a StatusID field,
+// reflect.TypeOf(StatusID(0)) with {"open": 142} accepts both status=142 and
+// status=open. Numeric values take precedence; unknown labels are conversion
+// failures, reported as field errors under WithStrictMode. Labels for a type that
+// is not an integer type are never consulted. The labels are copied, and the parser
+// is safe to share once built.
+func WithEnum(enumType reflect.Type, labels map[string]int64) Option {
+	copied := make(map[string]int64, len(labels))
+	for label, value := range labels {
+		copied[label] = value
+	}
+	return func(p *Parser) {
+		if p.enums == nil {
+			p.enums = make(map[reflect.Type]map[string]int64)
+		}
+		p.enums[enumType] = copied
+	}
+}
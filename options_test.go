@@ -0,0 +1,182 @@
+package parseform
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRepeatedKeyPolicy(t *testing.T) {
+	type item struct {
+		A string `form:"a"`
+		B string `form:"b"`
+	}
+	type form struct {
+		Name  string `form:"name"`
+		Items []item `form:"items"`
+	}
+	formData := "name=x&name=y&items[0][a]=1&items[0][a]=2&items[0][b]=3&items[1][b]=4"
+
+	tests := []struct {
+		name       string
+		policy     RepeatedKeyPolicy
+		want       form
+		wantMap    map[string]interface{}
+		wantFields []string
+	}{
+		{
+			name:    "first",
+			policy:  RepeatedKeyFirst,
+			want:    form{Name: "x", Items: []item{{A: "1", B: "3"}, {B: "4"}}},
+			wantMap: map[string]interface{}{"name": "x", "items": []interface{}{map[string]interface{}{"a": 1, "b": 3}, map[string]interface{}{"b": 4}}},
+		},
+		{
+			name:    "last",
+			policy:  RepeatedKeyLast,
+			want:    form{Name: "y", Items: []item{{A: "2", B: "3"}, {B: "4"}}},
+			wantMap: map[string]interface{}{"name": "y", "items": []interface{}{map[string]interface{}{"a": 2, "b": 3}, map[string]interface{}{"b": 4}}},
+		},
+		{
+			name:       "error",
+			policy:     RepeatedKeyError,
+			wantFields: []string{"items[0][a]", "name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(WithRepeatedKeyPolicy(tt.policy))
+
+			var got form
+			err := parser.ParseForm(formData, &got)
+			m, mapErr := parser.FormToMap(formData)
+
+			if tt.wantFields != nil {
+				for _, err := range []error{err, mapErr} {
+					errs, ok := err.(ValidationErrors)
+					if !ok || len(errs) != len(tt.wantFields) {
+						t.Fatalf("error = %v, want errors for %v", err, tt.wantFields)
+					}
+					for i, fieldErr := range errs {
+						if fieldErr.Field != tt.wantFields[i] || !errors.Is(fieldErr, ErrRepeatedKey) {
+							t.Errorf("error %d = %v, want a repeated key error for %s", i, fieldErr, tt.wantFields[i])
+						}
+					}
+				}
+				return
+			}
+
+			if err != nil || mapErr != nil {
+				t.Fatalf("ParseForm() error = %v, FormToMap() error = %v", err, mapErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(m, tt.wantMap) {
+				t.Errorf("FormToMap() = %#v, want %#v", m, tt.wantMap)
+			}
+		})
+	}
+}
+
+func TestOverlappingIndicesMerge(t *testing.T) {
+	type item struct {
+		A string `form:"a"`
+		B string `form:"b"`
+	}
+	var got struct {
+		Items []item `form:"items"`
+	}
+	formData := "items[0][a]=1&items[1][b]=2&items[0][b]=3&items[00][a]=4"
+	if err := NewParser(WithRepeatedKeyPolicy(RepeatedKeyError)).ParseForm(formData, &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+
+	want := []item{{A: "1", B: "3"}, {B: "2"}}
+	if !reflect.DeepEqual(got.Items, want) {
+		t.Errorf("Items = %+v, want %+v", got.Items, want)
+	}
+}
+
+func TestLeadingZeroIndicesAreKeys(t *testing.T) {
+	m, err := NewParser().FormToMap("a[0]=x&a[01]=y&b[00]=z")
+	if err != nil {
+		t.Fatalf("FormToMap() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"a": map[string]interface{}{"0": "x", "01": "y"},
+		"b": map[string]interface{}{"00": "z"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("FormToMap() = %#v, want %#v", m, want)
+	}
+}
+
+func TestIndexOverflowPolicy(t *testing.T) {
+	type form struct {
+		Fixed  [2]int `form:"fixed"`
+		Capped []int  `form:"capped"`
+	}
+	formData := "fixed[0]=1&fixed[2]=3&fixed[5]=6&capped[0]=1&capped[3]=4"
+
+	tests := []struct {
+		name       string
+		policy     IndexOverflowPolicy
+		want       form
+		wantFields []string
+	}{
+		{
+			name:   "ignore",
+			policy: IndexOverflowIgnore,
+			want:   form{Fixed: [2]int{1, 0}, Capped: []int{1}},
+		},
+		{
+			name:       "error",
+			policy:     IndexOverflowError,
+			want:       form{Fixed: [2]int{1, 0}, Capped: []int{1}},
+			wantFields: []string{"fixed[2]", "fixed[5]", "capped[3]"},
+		},
+		{
+			name:   "grow",
+			policy: IndexOverflowGrow,
+			want:   form{Fixed: [2]int{1, 0}, Capped: []int{1, 0, 0, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(WithMaxSliceLength(2), WithIndexOverflowPolicy(tt.policy)).ParseForm(formData, &got)
+
+			var fields []string
+			if errs, ok := err.(ValidationErrors); ok {
+				for _, fieldErr := range errs {
+					if !errors.Is(fieldErr, ErrIndexOverflow) {
+						t.Errorf("error = %v, want ErrIndexOverflow", fieldErr)
+					}
+					fields = append(fields, fieldErr.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("ParseForm() error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexOverflowWithoutCap(t *testing.T) {
+	var got struct {
+		Nums []int `form:"nums"`
+	}
+	if err := NewParser(WithIndexOverflowPolicy(IndexOverflowError)).ParseForm("nums[3]=4", &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if want := []int{0, 0, 0, 4}; !reflect.DeepEqual(got.Nums, want) {
+		t.Errorf("Nums = %v, want %v", got.Nums, want)
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/404th/parseform/parseformecho
+
+go 1.21
+
+require (
+	github.com/404th/parseform v0.0.0
+	github.com/labstack/echo/v4 v4.9.1
+)
+
+require (
+	github.com/labstack/gommon v0.4.0 // indirect
+	github.com/mattn/go-colorable v0.1.11 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.1 // indirect
+	golang.org/x/crypto v0.23.0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	golang.org/x/time v0.5.0 // indirect
+)
+
+replace github.com/404th/parseform => ../
@@ -0,0 +1,55 @@
+// Package parseformecho connects parseform to the Echo web framework. It lives in its
+// own module so the core parseform package stays free of dependencies.
+package parseformecho
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/404th/parseform"
+	"github.com/labstack/echo/v4"
+)
+
+// ContextKey is the echo.Context key Middleware stores the parsed form under
+const ContextKey = "parsed_form"
+
+// Middleware parses each request's form into a new T with a parser built from opts
+// and stores it in the context under ContextKey, where handlers read it with Get.
+// Bodies are parsed for methods that carry one and the query string otherwise. A
+// request that fails to parse is answered with 400 Bad Request, with the parse error
+// kept as the HTTPError's internal error.
+func Middleware[T any](opts ...parseform.Option) echo.MiddlewareFunc {
+	parser := parseform.NewParser(opts...)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var v T
+			if err := parseRequest(parser, c.Request(), &v); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+			}
+
+			c.Set(ContextKey, v)
+			return next(c)
+		}
+	}
+}
+
+// Get returns the form Middleware parsed for this request, reporting false when the
+// middleware did not run or was registered for a different type
+func Get[T any](c echo.Context) (T, bool) {
+	form, ok := c.Get(ContextKey).(T)
+	return form, ok
+}
+
+// parseRequest parses the request body, or the query string for methods without one
+func parseRequest(parser *parseform.Parser, req *http.Request, target any) error {
+	if req == nil {
+		return errors.New("missing request")
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return parser.ParseForm(req.URL.RawQuery, target)
+	}
+	return parser.ParseRequest(req, target)
+}
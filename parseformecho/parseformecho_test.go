@@ -0,0 +1,78 @@
+package parseformecho
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/404th/parseform"
+	"github.com/labstack/echo/v4"
+)
+
+type signup struct {
+	Name string `form:"name" required:"true"`
+	Age  int    `form:"age"`
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []parseform.Option
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+		wantErr    error
+	}{
+		{"plain body", nil, http.MethodPost, "/", "name=Ann&age=30", http.StatusOK, "Ann 30", nil},
+		{"query string", nil, http.MethodGet, "/?name=Bob&age=7", "", http.StatusOK, "Bob 7", nil},
+		{"missing required field", nil, http.MethodPost, "/", "age=30", http.StatusBadRequest, "", parseform.ErrRequired},
+		{"body within limit", []parseform.Option{parseform.WithMaxBodySize(32)}, http.MethodPost, "/", "name=Ann&age=30", http.StatusOK, "Ann 30", nil},
+		{"body over limit", []parseform.Option{parseform.WithMaxBodySize(8)}, http.MethodPost, "/", "name=Ann&age=30", http.StatusBadRequest, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerErr error
+			e := echo.New()
+			e.HTTPErrorHandler = func(err error, c echo.Context) {
+				handlerErr = err
+				e.DefaultHTTPErrorHandler(err, c)
+			}
+			e.Use(Middleware[signup](tt.opts...))
+			e.Any("/", func(c echo.Context) error {
+				form, ok := Get[signup](c)
+				if !ok {
+					return echo.ErrInternalServerError
+				}
+				return c.String(http.StatusOK, form.Name+" "+strconv.Itoa(form.Age))
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var httpErr *echo.HTTPError
+				if !errors.As(handlerErr, &httpErr) || httpErr.Internal == nil {
+					t.Fatalf("error = %v, want an HTTPError with the parse error inside", handlerErr)
+				}
+				if tt.wantErr != nil && !errors.Is(httpErr.Internal, tt.wantErr) {
+					t.Errorf("internal error = %v, want %v", httpErr.Internal, tt.wantErr)
+				}
+				return
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Package parseformfiber connects parseform to the Fiber web framework. Fiber is built
+// on fasthttp rather than net/http, so it needs an adapter of its own, and it lives in
+// its own module so the core parseform package stays free of dependencies.
+package parseformfiber
+
+import (
+	"github.com/404th/parseform"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContextKey is the fiber.Ctx locals key Middleware stores the parsed form under
+const ContextKey = "parsed_form"
+
+// Middleware parses each request's form into a new T with a parser built from opts
+// and stores it in the request's locals under ContextKey, where handlers read it with
+// Get. Bodies are parsed for methods that carry one and the query string otherwise.
+// A request that fails to parse is answered with 400 Bad Request.
+func Middleware[T any](opts ...parseform.Option) fiber.Handler {
+	parser := parseform.NewParser(opts...)
+
+	return func(c *fiber.Ctx) error {
+		var v T
+		if err := parseRequest(parser, c, &v); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		c.Locals(ContextKey, v)
+		return c.Next()
+	}
+}
+
+// Get returns the form Middleware parsed for this request, reporting false when the
+// middleware did not run or was registered for a different type
+func Get[T any](c *fiber.Ctx) (T, bool) {
+	form, ok := c.Locals(ContextKey).(T)
+	return form, ok
+}
+
+// parseRequest parses the request body, or the query string for methods without one.
+// The raw body is decompressed per its Content-Encoding by the parser, as ParseRequest
+// does for net/http, rather than through c.Body(), which hands back the text of a
+// decoding error as if it were the body.
+func parseRequest(parser *parseform.Parser, c *fiber.Ctx, target any) error {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodDelete, fiber.MethodOptions:
+		return parser.ParseForm(string(c.Request().URI().QueryString()), target)
+	}
+	return parser.ParseCompressed(c.Request().Body(), c.Get(fiber.HeaderContentEncoding), target)
+}
@@ -0,0 +1,84 @@
+package parseformfiber
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type signup struct {
+	Name string `form:"name"`
+	Age  int    `form:"age"`
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       []byte
+		encoding   string
+		wantStatus int
+		wantBody   string
+	}{
+		{"plain body", http.MethodPost, "/", []byte("name=Ann&age=30"), "", http.StatusOK, "Ann 30"},
+		{"gzip body", http.MethodPost, "/", gzipped(t, "name=Ann&age=30"), "gzip", http.StatusOK, "Ann 30"},
+		{"query string", http.MethodGet, "/?name=Bob&age=7", nil, "", http.StatusOK, "Bob 7"},
+		{"corrupt gzip body", http.MethodPost, "/", []byte("name=Ann"), "gzip", http.StatusBadRequest, ""},
+		{"unsupported encoding", http.MethodPost, "/", []byte("name=Ann"), "compress", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(Middleware[signup]())
+			app.All("/", func(c *fiber.Ctx) error {
+				form, ok := Get[signup](c)
+				if !ok {
+					return fiber.ErrInternalServerError
+				}
+				return c.SendString(form.Name + " " + strconv.Itoa(form.Age))
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+			if tt.encoding != "" {
+				req.Header.Set(fiber.HeaderContentEncoding, tt.encoding)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantBody != "" {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != tt.wantBody {
+					t.Errorf("body = %q, want %q", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package parseformgin connects parseform to the Gin web framework. It lives in its
+// own module so the core parseform package stays free of dependencies.
+package parseformgin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/404th/parseform"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ContextKey is the gin.Context key Middleware stores the parsed form under
+const ContextKey = "parsed_form"
+
+// Middleware parses each request's form into a new T with a parser built from opts
+// and stores it in the context under ContextKey, where handlers read it with Get.
+// Bodies are parsed for methods that carry one and the query string otherwise. A
+// request that fails to parse is aborted with 400 Bad Request and the error recorded
+// as a gin.ErrorTypeBind error, as Gin's own binding does.
+func Middleware[T any](opts ...parseform.Option) gin.HandlerFunc {
+	parser := parseform.NewParser(opts...)
+
+	return func(c *gin.Context) {
+		var v T
+		if err := parseRequest(parser, c.Request, &v); err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err).SetType(gin.ErrorTypeBind)
+			return
+		}
+
+		c.Set(ContextKey, v)
+		c.Next()
+	}
+}
+
+// Get returns the form Middleware parsed for this request, reporting false when the
+// middleware did not run or was registered for a different type
+func Get[T any](c *gin.Context) (T, bool) {
+	v, ok := c.Get(ContextKey)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	form, ok := v.(T)
+	return form, ok
+}
+
+// BindingMode adapts a parser to Gin's binding.Binding, so c.ShouldBindWith and
+// c.MustBindWith use parseform and report failures through Gin's usual binding
+// error handling
+type BindingMode struct {
+	Parser *parseform.Parser
+}
+
+// Binding returns a BindingMode using a parser built from opts
+func Binding(opts ...parseform.Option) BindingMode {
+	return BindingMode{Parser: parseform.NewParser(opts...)}
+}
+
+// Name implements binding.Binding
+func (BindingMode) Name() string {
+	return "parseform"
+}
+
+// Bind implements binding.Binding
+func (b BindingMode) Bind(req *http.Request, obj any) error {
+	parser := b.Parser
+	if parser == nil {
+		parser = parseform.NewParser()
+	}
+	return parseRequest(parser, req, obj)
+}
+
+var _ binding.Binding = BindingMode{}
+
+// parseRequest parses the request body, or the query string for methods without one
+func parseRequest(parser *parseform.Parser, req *http.Request, target any) error {
+	if req == nil {
+		return errors.New("missing request")
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return parser.ParseForm(req.URL.RawQuery, target)
+	}
+	return parser.ParseRequest(req, target)
+}
@@ -0,0 +1,114 @@
+package parseformgin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/404th/parseform"
+	"github.com/gin-gonic/gin"
+)
+
+type signup struct {
+	Name string `form:"name" required:"true"`
+	Age  int    `form:"age"`
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		opts       []parseform.Option
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"plain body", nil, http.MethodPost, "/", "name=Ann&age=30", http.StatusOK, "Ann 30"},
+		{"query string", nil, http.MethodGet, "/?name=Bob&age=7", "", http.StatusOK, "Bob 7"},
+		{"missing required field", nil, http.MethodPost, "/", "age=30", http.StatusBadRequest, ""},
+		{"body within limit", []parseform.Option{parseform.WithMaxBodySize(32)}, http.MethodPost, "/", "name=Ann&age=30", http.StatusOK, "Ann 30"},
+		{"body over limit", []parseform.Option{parseform.WithMaxBodySize(8)}, http.MethodPost, "/", "name=Ann&age=30", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bindErr error
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Next()
+				bindErr = c.Errors.ByType(gin.ErrorTypeBind).Last()
+			})
+			router.Use(Middleware[signup](tt.opts...))
+			router.Any("/", func(c *gin.Context) {
+				form, ok := Get[signup](c)
+				if !ok {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.String(http.StatusOK, form.Name+" "+strconv.Itoa(form.Age))
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if bindErr == nil {
+					t.Error("no gin.ErrorTypeBind error recorded")
+				}
+				return
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestBindingMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		body    string
+		want    signup
+		wantErr error
+	}{
+		{"valid", "name=Ann&age=30", signup{Name: "Ann", Age: 30}, nil},
+		{"missing required field", "age=30", signup{}, parseform.ErrRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = req
+
+			var got signup
+			err := c.ShouldBindWith(&got, Binding())
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ShouldBindWith() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ShouldBindWith() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ShouldBindWith() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
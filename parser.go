@@ -1,25 +1,100 @@
 package parseform
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Parser represents a form-urlencoded data parser
-type Parser struct{}
+type Parser struct {
+	keyedSlices      bool
+	dottedKeys       bool
+	jsonPointerKeys  bool
+	requireAllFields bool
+	skipReadonly     bool
+	merge            bool
+	lenient          bool
+	emptyNumberZero  bool
+	maxBodySize      int64
+	discriminators   map[string]typeDiscriminator
+	maxSliceLength   int
+	indexOverflow    IndexOverflowPolicy
+	repeatedKeys     RepeatedKeyPolicy
+	unknownKeysError bool
+	versionKey       string
+	versionMappings  map[string]map[string]string
+	ignoredErrors    []error
+	arrayDelimiter   string
+	validator        Validator
+	enums            map[reflect.Type]map[string]int64
+	strict           bool
+	depthFirst       bool
+	trueValues       []string
+	falseValues      []string
+	transforms       map[string]func(string) string
+	monthNames       map[string]time.Month
+	byteLength       bool
+	nullMarker       string
+	nullAsZero       bool
+	unescapeMode     UnescapeMode
+
+	// keyOrder records where each key first appears in the raw form data and
+	// rawForm holds that data. These are only set on the per-call copy made by
+	// the entry points.
+	keyOrder map[string]int
+	rawForm  *string
+
+	// metrics collects parse statistics for ParseFormWithMetrics
+	metrics *ParseFormMetrics
+
+	// warnings collects failed conversions for ParseFormLenient, and typeErrors
+	// collects them as field errors under WithStrictMode
+	warnings   *[]FieldError
+	typeErrors *ValidationErrors
+
+	// appendSlices makes slice fields grow rather than be overwritten, for ParseFormAppend
+	appendSlices bool
+
+	// recoverFields turns errors that would stop the parse at a field into field
+	// errors, for ParseFormRecover
+	recoverFields bool
+
+	// trace records each field's outcome for ParseFormWithDebug, and failedPaths
+	// lists the paths of values that could not be converted for it
+	trace       *ParseTrace
+	failedPaths []string
+}
+
+// maxArrayIndex bounds bracket indices treated as array positions, so a single key
+// like items[999999999] cannot force a huge allocation. Larger indices are treated
+// as object keys.
+const maxArrayIndex = 1 << 16
+
+var (
+	// bracketSuffixPattern matches a well-formed run of bracket groups like [a][0]
+	bracketSuffixPattern = regexp.MustCompile(`^(\[[^\[\]]*\])+$`)
+
+	// bracketGroupPattern matches a single non-empty bracket group
+	bracketGroupPattern = regexp.MustCompile(`\[([^\]]+)\]`)
+)
 
 // keyGroup represents a group of related form keys
 type keyGroup struct {
 	baseKey   string
 	value     interface{} // Change from string to interface{}
+	raw       string      // value as it appeared in the form, before type conversion
 	isSimple  bool
-	isArray   bool
-	isObject  bool
 	children  map[string]*keyGroup
 	arrayData map[int]*keyGroup
 }
@@ -33,28 +108,242 @@ type parsedKey struct {
 	path       []string
 }
 
-// NewParser creates a new parser instance
-func NewParser() *Parser {
-	return &Parser{}
+// NewParser creates a new parser instance configured with the given options
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ParseForm parses form-urlencoded data into a struct
 func (p *Parser) ParseForm(formData string, target interface{}) error {
+	// Per-call state lives on a copy so the parser stays safe for concurrent use
+	session := *p
+	return session.parseFormData(formData, target)
+}
+
+// ParseFormWithMetrics parses form data into a struct like ParseForm and reports parse statistics
+func (p *Parser) ParseFormWithMetrics(formData string, target interface{}) (ParseFormMetrics, error) {
+	var metrics ParseFormMetrics
+	session := *p
+	session.metrics = &metrics
+
+	start := time.Now()
+	err := session.parseFormData(formData, target)
+	metrics.Duration = time.Since(start)
+
+	return metrics, err
+}
+
+// ParseFormLenient parses form data into a struct on a best-effort basis: a value that
+// cannot be converted sets its field to the zero value and is reported as a warning
+// instead of being skipped silently. err is still returned for malformed input and
+// validation failures.
+func (p *Parser) ParseFormLenient(formData string, target interface{}) (warnings []FieldError, err error) {
+	session := *p
+	session.warnings = &warnings
+	err = session.parseFormData(formData, target)
+	return warnings, err
+}
+
+// ParseFormRecover parses form data into a struct without stopping at the first field
+// that fails: errors that would abort ParseForm, like an unregistered transform or an
+// invalid pattern tag, are reported as field errors and parsing continues with the
+// next field. A panic during parsing is recovered and returned as an error.
+// partiallyFilled reports whether fields failed, leaving target with only the fields
+// that parsed; err then lists every failure.
+func (p *Parser) ParseFormRecover(formData string, target interface{}) (partiallyFilled bool, err error) {
+	session := *p
+	session.recoverFields = true
+	defer func() {
+		if r := recover(); r != nil {
+			partiallyFilled, err = true, fmt.Errorf("parsing panicked: %v", r)
+		}
+	}()
+
+	err = session.parseFormData(formData, target)
+	var fieldErrs ValidationErrors
+	return errors.As(err, &fieldErrs), err
+}
+
+// recoverField records err, which would otherwise stop the parse at the field at path,
+// as a field error under ParseFormRecover and reports whether it did
+func (p *Parser) recoverField(errs *ValidationErrors, tag formTag, path string, err error) bool {
+	if !p.recoverFields {
+		return false
+	}
+	*errs = append(*errs, p.fieldError(tag, path, err.Error(), err))
+	return true
+}
+
+// ParseFormAppend parses form data into a struct that already holds data, such as
+// the result of an earlier step of a multi-step form. Submitted slice elements are
+// appended after the existing ones instead of replacing them, so with Tags holding
+// [{ID: 1, Name: "go"}], tags[0][name]=web yields [{ID: 1, Name: "go"}, {Name: "web"}].
+// Other fields are merged as under WithMerge.
+func (p *Parser) ParseFormAppend(formData string, target interface{}) error {
+	session := *p
+	session.merge = true
+	session.appendSlices = true
+	return session.parseFormData(formData, target)
+}
+
+// parseFormData parses raw form data into a struct. It must be called on a
+// per-call copy of the parser because it records per-call state.
+func (p *Parser) parseFormData(formData string, target interface{}) error {
 	// Parse the form data
 	values, err := url.ParseQuery(formData)
 	if err != nil {
 		return fmt.Errorf("failed to parse form data: %w", err)
 	}
 
+	p.rawForm = &formData
+	if p.keyedSlices {
+		p.keyOrder = p.formKeyOrder(formData)
+	}
+
 	// Parse into target struct
 	return p.parseIntoStruct(values, target)
 }
 
+// formKeyOrder records the position at which each key first appears in formData
+func (p *Parser) formKeyOrder(formData string) map[string]int {
+	order := make(map[string]int)
+	for i, pair := range strings.Split(formData, "&") {
+		key, _, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if p.dottedKeys {
+			key = p.expandDottedKey(key)
+		}
+		if p.jsonPointerKeys {
+			key = p.jsonPointerKey(key)
+		}
+		if _, seen := order[key]; !seen {
+			order[key] = i
+		}
+	}
+	return order
+}
+
 // ParseFormBytes parses form-urlencoded data from bytes into a struct
 func (p *Parser) ParseFormBytes(data []byte, target interface{}) error {
 	return p.ParseForm(string(data), target)
 }
 
+// Get returns the first value for a single key without parsing the whole form.
+// The path may use bracket notation (leads[status][0][id]) or dots (leads.status.0.id).
+func (p *Parser) Get(formData, path string) (string, bool) {
+	path = p.expandDottedKey(path)
+
+	for _, pair := range strings.Split(formData, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if key != path && p.expandDottedKey(key) != path {
+			continue
+		}
+
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			return unescaped, true
+		}
+		return value, true
+	}
+
+	return "", false
+}
+
+// ParseValues parses already decoded values, such as r.URL.Query() or values built by
+// hand, into a struct without re-encoding them. Values carry no key order, so keyed
+// slice elements are ordered by key.
+func (p *Parser) ParseValues(values url.Values, target interface{}) error {
+	session := *p
+	return session.parseIntoStruct(values, target)
+}
+
+// ParseFormFromKV parses flat key=value text, one pair per line, into a struct, as
+// found in .env files and simple configuration formats. Values are taken literally,
+// without percent-decoding; whitespace around keys and values and one pair of
+// matching quotes around a value are removed. Blank lines and lines starting with #
+// are skipped, and any other line without "=" is an error.
+func (p *Parser) ParseFormFromKV(kvText string, target interface{}) error {
+	values := make(url.Values)
+	for i, line := range strings.Split(kvText, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return fmt.Errorf("line %d: missing '=' in %q", i+1, line)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values.Add(key, value)
+	}
+
+	session := *p
+	return session.parseIntoStruct(values, target)
+}
+
+// ParseHTTPPostForm parses an already populated *http.Request.PostForm into a struct.
+// Call r.ParseForm() first; this avoids re-encoding the values just to parse them again.
+func (p *Parser) ParseHTTPPostForm(postForm url.Values, target interface{}) error {
+	return p.ParseValues(postForm, target)
+}
+
+// ParseFormFromJSON parses a JSON object into a struct using the same form tags as ParseForm.
+// {"user": {"name": "Alice"}} fills the same fields as user[name]=Alice, and null as if
+// the key were absent. Numbers keep their JSON text, so one a field cannot hold, like
+// 1e400, is a failed conversion: the field is left zero without an error unless
+// WithStrictMode is set.
+func (p *Parser) ParseFormFromJSON(jsonBody []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBody))
+	decoder.UseNumber()
+
+	var data map[string]interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("failed to parse JSON data: %w", err)
+	}
+
+	// Flatten the JSON tree into bracket notation keys
+	values := make(url.Values)
+	for key, value := range data {
+		p.flattenJSONValue(key, value, values)
+	}
+
+	session := *p
+	raw := string(jsonBody)
+	session.rawForm = &raw
+
+	return session.parseIntoStruct(values, target)
+}
+
+// flattenJSONValue adds a decoded JSON value to values under bracket notation keys
+func (p *Parser) flattenJSONValue(key string, value interface{}, values url.Values) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for childKey, child := range v {
+			p.flattenJSONValue(key+"["+childKey+"]", child, values)
+		}
+	case []interface{}:
+		for index, child := range v {
+			p.flattenJSONValue(fmt.Sprintf("%s[%d]", key, index), child, values)
+		}
+	case nil:
+		// null carries no value
+	default:
+		values.Add(key, fmt.Sprint(v))
+	}
+}
+
 // parseIntoStruct parses url.Values data into a struct
 func (p *Parser) parseIntoStruct(values url.Values, target interface{}) error {
 	targetValue := reflect.ValueOf(target)
@@ -67,55 +356,930 @@ func (p *Parser) parseIntoStruct(values url.Values, target interface{}) error {
 		return fmt.Errorf("target must be a pointer to struct")
 	}
 
-	return p.parseStruct(values, targetElem)
+	if p.dottedKeys {
+		values = p.rewriteKeys(values, p.expandDottedKey)
+	}
+	if p.jsonPointerKeys {
+		values = p.rewriteKeys(values, p.jsonPointerKey)
+	}
+	if mapping := p.versionMapping(values); mapping != nil {
+		values = p.rewriteKeys(values, func(key string) string {
+			return p.versionedKey(key, mapping)
+		})
+	}
+	values = p.expandEmptyBrackets(values)
+	if err := p.checkRepeatedKeys(values); err != nil {
+		return err
+	}
+
+	// Under strict mode, failed conversions become field errors alongside the others
+	var typeErrs ValidationErrors
+	if p.strict && p.warnings == nil {
+		p.typeErrors = &typeErrs
+	}
+	var errs ValidationErrors
+	if err := collectFieldErrors(&errs, p.parseStruct(values, targetElem)); err != nil {
+		return err
+	}
+	if p.unknownKeysError {
+		errs = append(errs, p.unknownKeys(values, targetElem.Type())...)
+	}
+	errs = append(typeErrs, errs...)
+	p.ignoreErrors(&errs, 0)
+	return errs.errOrNil()
+}
+
+// versionMapping returns the WithVersionedMapping key mapping for the version the
+// values carry, or nil when there is none
+func (p *Parser) versionMapping(values url.Values) map[string]string {
+	if p.versionKey == "" {
+		return nil
+	}
+	version := values[p.versionKey]
+	if len(version) == 0 {
+		return nil
+	}
+	return p.versionMappings[p.leafValue(version)]
+}
+
+// versionedKey renames a key per a version's mapping, matching the whole key first
+// and then its base name
+func (p *Parser) versionedKey(key string, mapping map[string]string) string {
+	if renamed, mapped := mapping[key]; mapped {
+		return renamed
+	}
+	base, rest, nested := strings.Cut(key, "[")
+	if renamed, mapped := mapping[base]; mapped && nested {
+		return renamed + "[" + rest
+	}
+	return key
+}
+
+// leafValue picks the value of a key from all of its values per the repeated key policy
+func (p *Parser) leafValue(valueSlice []string) string {
+	if p.repeatedKeys == RepeatedKeyLast {
+		return valueSlice[len(valueSlice)-1]
+	}
+	return valueSlice[0]
+}
+
+// checkRepeatedKeys reports every key with more than one value under RepeatedKeyError
+func (p *Parser) checkRepeatedKeys(values url.Values) error {
+	if p.repeatedKeys != RepeatedKeyError {
+		return nil
+	}
+
+	var errs ValidationErrors
+	for key, valueSlice := range values {
+		if len(valueSlice) > 1 {
+			errs = append(errs, FieldError{Field: key, Message: fmt.Sprintf("is repeated %d times", len(valueSlice)), Err: ErrRepeatedKey})
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs.errOrNil()
+}
+
+// rewriteKeys returns values with every key passed through rewrite, merging keys that collide
+func (p *Parser) rewriteKeys(values url.Values, rewrite func(string) string) url.Values {
+	rewritten := make(url.Values, len(values))
+	for key, valueSlice := range values {
+		newKey := rewrite(key)
+		rewritten[newKey] = append(rewritten[newKey], valueSlice...)
+	}
+	return rewritten
+}
+
+// jsonPointerKey rewrites an RFC 6901 JSON Pointer key like "/leads/status/0/name"
+// as "leads[status][0][name]", unescaping ~1 to "/" and ~0 to "~" in each segment.
+// Keys that do not start with "/" are returned unchanged.
+func (p *Parser) jsonPointerKey(key string) string {
+	if !strings.HasPrefix(key, "/") {
+		return key
+	}
+
+	segments := strings.Split(key[1:], "/")
+	if segments[0] == "" {
+		return key
+	}
+
+	unescaper := strings.NewReplacer("~1", "/", "~0", "~")
+	for i, segment := range segments {
+		segments[i] = unescaper.Replace(segment)
+	}
+
+	if len(segments) == 1 {
+		return segments[0]
+	}
+	return segments[0] + "[" + strings.Join(segments[1:], "][") + "]"
+}
+
+// expandDottedKey rewrites a key like "user.profile.name" as "user[profile][name]".
+// Dots inside brackets and keys with empty dot segments are left untouched.
+func (p *Parser) expandDottedKey(key string) string {
+	base, rest := key, ""
+	if openBracket := strings.Index(key, "["); openBracket >= 0 {
+		base, rest = key[:openBracket], key[openBracket:]
+	}
+
+	parts := strings.Split(base, ".")
+	if len(parts) == 1 {
+		return key
+	}
+	for _, part := range parts {
+		if part == "" {
+			return key
+		}
+	}
+
+	return parts[0] + "[" + strings.Join(parts[1:], "][") + "]" + rest
+}
+
+// expandEmptyBrackets rewrites append-style keys such as tags[]=a&tags[]=b into
+// explicit indices (tags[0]=a&tags[1]=b), following PHP's array push semantics:
+// every value of an empty-bracket key becomes a new element after the highest
+// index already in use. Append keys are resolved in sorted key order.
+func (p *Parser) expandEmptyBrackets(values url.Values) url.Values {
+	var appendKeys []string
+	for key := range values {
+		if strings.Contains(key, "[]") {
+			appendKeys = append(appendKeys, key)
+		}
+	}
+	if len(appendKeys) == 0 {
+		return values
+	}
+	sort.Strings(appendKeys)
+
+	// Explicit indices are kept, and the next free index under each prefix is tracked
+	expanded := make(url.Values, len(values))
+	nextIndex := make(map[string]int)
+	for key, valueSlice := range values {
+		p.trackArrayIndices(key, nextIndex)
+		if !strings.Contains(key, "[]") {
+			expanded[key] = valueSlice
+		}
+	}
+
+	for _, key := range appendKeys {
+		for _, value := range values[key] {
+			resolved := p.resolveEmptyBrackets(key, nextIndex)
+			expanded[resolved] = append(expanded[resolved], value)
+		}
+	}
+
+	return expanded
+}
+
+// trackArrayIndices records, for every array index in key, the next free index under its prefix
+func (p *Parser) trackArrayIndices(key string, nextIndex map[string]int) {
+	for pos := strings.Index(key, "["); pos > 0; {
+		end := strings.Index(key[pos:], "]")
+		if end < 0 {
+			return
+		}
+
+		prefix := key[:pos]
+		if index, ok := p.arrayIndex(key[pos+1 : pos+end]); ok && index >= nextIndex[prefix] {
+			nextIndex[prefix] = index + 1
+		}
+
+		next := strings.Index(key[pos+end:], "[")
+		if next < 0 {
+			return
+		}
+		pos += end + next
+	}
+}
+
+// resolveEmptyBrackets replaces each empty bracket in key with the next free index under its prefix
+func (p *Parser) resolveEmptyBrackets(key string, nextIndex map[string]int) string {
+	for {
+		pos := strings.Index(key, "[]")
+		if pos <= 0 {
+			return key
+		}
+
+		prefix := key[:pos]
+		index := nextIndex[prefix]
+		nextIndex[prefix] = index + 1
+		key = prefix + "[" + strconv.Itoa(index) + "]" + key[pos+2:]
+	}
 }
 
 // parseStruct recursively parses data into a struct
 func (p *Parser) parseStruct(values url.Values, structValue reflect.Value) error {
 	structType := structValue.Type()
+	var errs ValidationErrors
+	var groups oneofGroups
+	var conditions requiredIfFields
+	var rawField string
+
+	// Under depth-first consumption each key is claimed by exactly one field
+	var owners map[string]int
+	if p.depthFirst {
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		owners = p.fieldOwners(structType, keys)
+	}
 
 	for i := 0; i < structValue.NumField(); i++ {
 		field := structValue.Field(i)
 		fieldType := structType.Field(i)
 
 		// Get the form tag or use field name
-		formTag := fieldType.Tag.Get("form")
-		fieldName := fieldType.Name
-		if formTag != "" {
-			fieldName = formTag
+		tag, skip := p.parseFormTag(structType, fieldType)
+		if skip {
+			continue
+		}
+		fieldName := tag.name
+
+		// A raw field receives the unparsed form data rather than a form value
+		if tag.hasOption("raw") {
+			if rawField != "" {
+				return fmt.Errorf("only one raw field is allowed, found %s and %s", rawField, fieldType.Name)
+			}
+			rawField = fieldType.Name
+			if err := p.setValue(field, p.rawFormData(values), fieldName); err != nil {
+				return fmt.Errorf("failed to set raw field %s: %w", fieldType.Name, err)
+			}
+			continue
 		}
 
 		// Try to find matching data for this field
-		fieldData := p.findFieldData(values, fieldName)
+		fieldValues := values
+		if owners != nil {
+			fieldValues = p.ownedValues(values, owners, i)
+		}
+		fieldData := p.findFieldData(fieldValues, fieldName)
+		if keys := tag.joinedKeys(); keys != nil {
+			fieldData = p.joinFieldData(keys, tag, func(key string) (string, bool) {
+				value := values[key]
+				if len(value) == 0 {
+					return "", false
+				}
+				return p.leafValue(value), true
+			})
+		}
+		if fieldData != nil && field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Bool {
+			fieldData = p.checkboxSetData(fieldData, fieldValues[fieldName], fieldName)
+		}
+		if repeated := fieldValues[fieldName]; len(repeated) > 1 && tag.joinedKeys() == nil && p.isRepeatableType(field.Type()) {
+			fieldData = p.splitSliceValues(fieldData, repeated, tag)
+		}
+		if _, zipped := tag.structTag.Lookup("zip"); zipped {
+			zipData, err := p.zipFieldData(tag, fieldName, func(key string) []string {
+				return values[key]
+			})
+			if err != nil {
+				if p.recoverField(&errs, tag, fieldName, err) {
+					continue
+				}
+				return err
+			}
+			fieldData = zipData
+		}
+		if group := fieldType.Tag.Get("oneof"); group != "" {
+			groups.add(group, fieldName, fieldData != nil)
+		}
+		p.recordField(fieldData != nil)
+		mark := p.markTrace(len(errs))
 		if fieldData == nil {
+			if tag.presence() && field.Kind() == reflect.Bool {
+				field.SetBool(false)
+			} else if tag.required() {
+				errs = append(errs, p.fieldError(tag, fieldName, "is required", ErrRequired))
+			} else if _, conditional := tag.structTag.Lookup("requiredIf"); conditional {
+				conditions.add(tag, fieldName)
+			} else if p.requireAllFields && !tag.hasOption("readonly") {
+				errs = append(errs, p.fieldError(tag, fieldName, "is missing", ErrRequired))
+			}
+			p.traceField(mark, structType, fieldType, tag, fieldName, fieldData, errs)
+			continue
+		}
+
+		// Read-only fields are set server-side and must not come from the form
+		if tag.hasOption("readonly") {
+			if !p.skipReadonly {
+				errs = append(errs, p.fieldError(tag, fieldName, "is read-only", ErrReadOnly))
+			}
+			p.traceField(mark, structType, fieldType, tag, fieldName, fieldData, errs)
 			continue
 		}
 
 		// Parse the field value
-		if err := p.parseFieldValue(field, fieldData, fieldName); err != nil {
+		start, typeStart := len(errs), p.typeErrorCount()
+		if err := collectFieldErrors(&errs, p.parseFieldValue(field, fieldData, tag, fieldName)); err != nil {
+			if p.recoverField(&errs, tag, fieldName, err) {
+				p.traceField(mark, structType, fieldType, tag, fieldName, fieldData, errs)
+				continue
+			}
+			if msg := tag.message(); msg != "" {
+				return FieldError{Field: fieldName, Message: msg, Err: err}
+			}
 			return fmt.Errorf("failed to parse field %s: %w", fieldName, err)
 		}
+		p.applyFieldMessage(errs[start:], tag, fieldName)
+		if p.typeErrors != nil {
+			p.applyFieldMessage((*p.typeErrors)[typeStart:], tag, fieldName)
+		}
+		fieldErrs, err := p.validateField(field, tag, fieldName)
+		if err != nil {
+			if p.recoverField(&errs, tag, fieldName, err) {
+				p.traceField(mark, structType, fieldType, tag, fieldName, fieldData, errs)
+				continue
+			}
+			return err
+		}
+		errs = append(errs, fieldErrs...)
+		p.zeroIgnored(field, fieldName, p.ignoreErrors(&errs, start))
+		p.traceField(mark, structType, fieldType, tag, fieldName, fieldData, errs)
+	}
+
+	conditionErrs, err := p.checkRequiredIf(structValue, conditions)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, conditionErrs...)
+	errs = append(errs, groups.validate()...)
+	p.ignoreErrors(&errs, 0)
+	return errs.errOrNil()
+}
+
+// formTag holds the key and options of a struct field's form tag, along with the
+// field's full struct tag for the other tags the parser reads
+type formTag struct {
+	name      string
+	options   []string
+	structTag reflect.StructTag
+}
+
+// hasOption reports whether the tag lists the given option
+func (t formTag) hasOption(option string) bool {
+	for _, opt := range t.options {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// required reports whether the field must be present in the form, via required:"true"
+func (t formTag) required() bool {
+	return t.structTag.Get("required") == "true"
+}
+
+// joinedKeys returns the source keys of a composite field like form:"first_name+last_name",
+// or nil for an ordinary field
+func (t formTag) joinedKeys() []string {
+	if !strings.Contains(t.name, "+") {
+		return nil
+	}
+	return strings.Split(t.name, "+")
+}
+
+// option returns the value of a key=value option, like format=hex
+func (t formTag) option(key string) (string, bool) {
+	for _, opt := range t.options {
+		if value, found := strings.CutPrefix(opt, key+"="); found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// message returns the field's custom error message: its msg tag, or else its
+// errmsg=... option, like form:"age,errmsg=Please enter your age". An errmsg
+// cannot contain commas.
+func (t formTag) message() string {
+	if msg := t.structTag.Get("msg"); msg != "" {
+		return msg
+	}
+	msg, _ := t.option("errmsg")
+	return msg
+}
+
+// numberBase returns the base integer fields are parsed in, chosen by the
+// format=hex, format=octal or format=binary option and decimal otherwise
+func (t formTag) numberBase() int {
+	format, _ := t.option("format")
+	switch format {
+	case "hex":
+		return 16
+	case "octal":
+		return 8
+	case "binary":
+		return 2
+	}
+	return 10
+}
+
+// presence reports whether the field is a checkbox-style boolean set by the key's presence alone
+func (t formTag) presence() bool {
+	return t.structTag.Get("presence") == "true"
+}
+
+// parseFormTag reads a struct field's form tag, like form:"name,raw", and reports
+// whether the field is skipped. The key defaults to the field name. Unexported
+// fields, fields tagged form:"-" and synchronization primitives such as an
+// embedded sync.Mutex are skipped.
+func (p *Parser) parseFormTag(structType reflect.Type, fieldType reflect.StructField) (formTag, bool) {
+	tagValue := fieldType.Tag.Get("form")
+	if tagValue == "-" || !fieldType.IsExported() || p.isSyncType(fieldType.Type) {
+		return formTag{}, true
+	}
+
+	parts := strings.Split(tagValue, ",")
+	tag := formTag{name: parts[0], options: parts[1:], structTag: fieldType.Tag}
+	if name, ok := dynamicFieldName(structType, fieldType.Name); ok {
+		if name == "-" {
+			return formTag{}, true
+		}
+		tag.name = name
+	}
+	if tag.name == "" {
+		tag.name = fieldType.Name
+	}
+
+	return tag, false
+}
+
+// FormFieldNamer is implemented by structs that choose their form keys at runtime,
+// such as generated types. FormFieldNames maps Go field names to form keys; a mapped
+// key replaces the name in the field's form tag, whose options still apply, and "-"
+// skips the field. Fields it does not map keep their static tags. It is called on
+// the struct's zero value, with a pointer receiver if the method has one.
+type FormFieldNamer interface {
+	FormFieldNames() map[string]string
+}
+
+// formFieldNamerType is the reflect.Type of FormFieldNamer
+var formFieldNamerType = reflect.TypeOf((*FormFieldNamer)(nil)).Elem()
+
+// dynamicFieldName looks up the form key FormFieldNames gives a field of structType
+func dynamicFieldName(structType reflect.Type, fieldName string) (string, bool) {
+	if !reflect.PointerTo(structType).Implements(formFieldNamerType) {
+		return "", false
+	}
+	name, ok := reflect.New(structType).Interface().(FormFieldNamer).FormFieldNames()[fieldName]
+	return name, ok
+}
+
+// isSyncType reports whether t, or the type it points to, comes from the sync package
+func (p *Parser) isSyncType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.PkgPath() == "sync"
+}
+
+// rawFormData returns the original form data of the current parse, re-encoding
+// values when the parse did not start from raw data
+func (p *Parser) rawFormData(values url.Values) string {
+	if p.rawForm != nil {
+		return *p.rawForm
+	}
+	return values.Encode()
+}
+
+// findFieldData finds data that matches a field name (including nested notation)
+func (p *Parser) findFieldData(values url.Values, fieldName string) map[string]string {
+	result := make(map[string]string)
+
+	// Look for exact matches and nested matches
+	for key, valueSlice := range values {
+		if len(valueSlice) == 0 {
+			continue
+		}
+
+		// Only the exact key or fieldName followed by a bracket belongs to the field, so
+		// status_id never matches id. A nested key must close its bracket, otherwise a
+		// malformed key like id[id would collide with the field's own value.
+		if key == fieldName {
+			result[key] = p.leafValue(valueSlice)
+		} else if strings.HasPrefix(key, fieldName+"[") {
+			// Extract nested part - keep the full nested key with brackets
+			nestedKey := key[len(fieldName)+1:] // Remove fieldName[ but keep the rest
+			if strings.Contains(nestedKey, "]") {
+				result[nestedKey] = p.leafValue(valueSlice)
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// parseFieldValue parses a single field value. path is the field's full bracket
+// notation key, such as "user[profile]", used to relate nested data back to the form.
+func (p *Parser) parseFieldValue(field reflect.Value, fieldData map[string]string, tag formTag, path string) error {
+	fieldName := tag.name
+
+	// Zipped elements report values that do not convert even outside strict mode,
+	// since a source split wrongly would otherwise leave zero fields behind
+	if _, zipped := tag.structTag.Lookup("zip"); zipped && p.typeErrors == nil && p.warnings == nil {
+		var typeErrs, errs ValidationErrors
+		p.typeErrors = &typeErrs
+		err := collectFieldErrors(&errs, p.parseFieldValue(field, fieldData, tag, path))
+		p.typeErrors = nil
+		if err != nil {
+			return err
+		}
+		return append(typeErrs, errs...).errOrNil()
+	}
+
+	// Pointer fields, like *Address or *int, are allocated and point at the parsed value,
+	// starting from the current one when merging. Pointers to interfaces are left to
+	// their discriminator below.
+	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() != reflect.Interface {
+		elem := reflect.New(field.Type().Elem())
+		if p.merge && !field.IsNil() {
+			elem.Elem().Set(field.Elem())
+		}
+		var errs ValidationErrors
+		if err := collectFieldErrors(&errs, p.parseFieldValue(elem.Elem(), fieldData, tag, path)); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return errs.errOrNil()
+	}
+
+	fieldData, err := p.transformFieldData(fieldData, tag, path)
+	if err != nil {
+		return err
+	}
+
+	// Under merge, an omitempty field treats a submitted zero value as "no change"
+	if p.merge && tag.hasOption("omitempty") {
+		previous := reflect.New(field.Type()).Elem()
+		previous.Set(field)
+		defer func() {
+			if field.IsZero() {
+				field.Set(previous)
+			}
+		}()
+	}
+
+	// Handle fields whose value arrives JSON-encoded, like tags=["a","b"]
+	if tag.structTag.Get("nested") == "json" {
+		if value, exists := fieldData[fieldName]; exists {
+			p.parseJSONValue(field, value, path)
+		}
+		return nil
+	}
+
+	// Handle fields carrying an XML fragment, like config=<config><mode>live</mode></config>
+	if tag.hasOption("xml") {
+		if value, exists := fieldData[fieldName]; exists {
+			p.parseXMLValue(field, value, path)
+		}
+		return nil
+	}
+
+	// Checkbox-style booleans are true whenever their key is present, even when empty
+	if tag.presence() && field.Kind() == reflect.Bool {
+		field.SetBool(true)
+		return nil
+	}
+
+	// Handle different field types
+	switch field.Kind() {
+	case reflect.String:
+		for _, value := range fieldData {
+			field.SetString(value)
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		for _, value := range fieldData {
+			if p.handleEmptyNumber(field, value) {
+				return nil
+			}
+			if scale, scaled := tag.structTag.Lookup("scale"); scaled {
+				return p.setScaledValue(field, value, scale, path)
+			}
+			intVal, err := p.parseIntValue(value, tag.numberBase())
+			if field.Kind() == reflect.Int32 && (err != nil || tag.hasOption("rune")) {
+				// rune is an alias of int32, so single characters like "," are accepted
+				if r, ok := p.runeValue(value); ok {
+					intVal, err = int64(r), nil
+				}
+			}
+			if enumVal, ok := p.enumValue(field.Type(), value); err != nil && ok {
+				intVal, err = enumVal, nil
+			}
+			if err == nil && !field.OverflowInt(intVal) {
+				field.SetInt(intVal)
+				return nil
+			}
+		}
+		p.recordTypeError(field, path, fieldData[fieldName])
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		for _, value := range fieldData {
+			if p.handleEmptyNumber(field, value) {
+				return nil
+			}
+			if scale, scaled := tag.structTag.Lookup("scale"); scaled {
+				return p.setScaledValue(field, value, scale, path)
+			}
+			uintVal, err := p.parseUintValue(value, tag.numberBase())
+			if enumVal, ok := p.enumValue(field.Type(), value); err != nil && ok && enumVal >= 0 {
+				uintVal, err = uint64(enumVal), nil
+			}
+			if err == nil && !field.OverflowUint(uintVal) {
+				field.SetUint(uintVal)
+				return nil
+			}
+		}
+		p.recordTypeError(field, path, fieldData[fieldName])
+
+	case reflect.Float32, reflect.Float64:
+		for _, value := range fieldData {
+			if p.handleEmptyNumber(field, value) {
+				return nil
+			}
+			if floatVal, err := p.parseFloatValue(value); err == nil && !field.OverflowFloat(floatVal) {
+				field.SetFloat(floatVal)
+				return nil
+			}
+		}
+		p.recordTypeError(field, path, fieldData[fieldName])
+
+	case reflect.Bool:
+		for _, value := range fieldData {
+			if boolVal, err := p.parseBoolValue(value); err == nil {
+				field.SetBool(boolVal)
+				return nil
+			}
+		}
+		p.recordTypeError(field, path, fieldData[fieldName])
+
+	case reflect.Struct:
+		// Handle time.Time from epoch seconds (with an optional fraction) or RFC 3339,
+		// or with the field's layout tag
+		if field.Type() == timeType {
+			value := fieldData[fieldName]
+			parse := p.parseTimeValue
+			if layout := tag.structTag.Get("layout"); layout != "" {
+				parse = func(value string) (time.Time, error) { return p.parseTimeLayout(value, layout) }
+			}
+			if t, err := parse(value); err == nil {
+				field.Set(reflect.ValueOf(t))
+			} else {
+				p.recordTypeError(field, path, value)
+			}
+			return nil
+		}
+
+		// Handle nested structs
+		if field.CanSet() {
+			// Create a new instance of the struct type, starting from the current value when merging
+			newStruct := reflect.New(field.Type()).Elem()
+			if p.merge {
+				newStruct.Set(field)
+			}
+			var errs ValidationErrors
+			if err := collectFieldErrors(&errs, p.parseStructFromMap(fieldData, newStruct, path)); err != nil {
+				return err
+			}
+			field.Set(newStruct)
+			return errs.errOrNil()
+		}
+
+	case reflect.Array:
+		// Handle [N]byte given as a single, optionally encoded, value
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			if value, exists := fieldData[fieldName]; exists {
+				return p.setByteArray(field, value, tag, path)
+			}
+		}
+
+		// Handle fixed-size arrays like slices, within their length
+		return p.parseSlice(field, p.splitSliceValue(fieldData, tag), path)
+
+	case reflect.Slice:
+		// Handle []byte as the raw decoded value rather than an indexed slice
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			if value, exists := fieldData[fieldName]; exists {
+				data, err := p.decodeBytes(value, tag, path)
+				if err != nil {
+					return err
+				}
+				field.SetBytes(data)
+			}
+			return nil
+		}
+
+		// Handle slices, dropping repeated elements when the field is tagged dedup
+		err := p.parseSlice(field, p.splitSliceValue(fieldData, tag), path)
+		if by, dedup := tag.structTag.Lookup("dedup"); dedup {
+			if dedupErr := p.dedupSlice(field, by, path); dedupErr != nil {
+				return dedupErr
+			}
+		}
+		return err
+
+	case reflect.Map:
+		// Handle maps, swapping keys and values for producers that send them inverted
+		if tag.structTag.Get("invert") == "true" {
+			fieldData = p.invertMapData(fieldData)
+		}
+		if value, exists := fieldData[fieldName]; exists && field.Type().Elem().Kind() == reflect.Bool {
+			fieldData = p.checkboxSetData(fieldData, []string{value}, fieldName)
+		}
+		return p.parseMap(field, fieldData, path)
+
+	case reflect.Interface:
+		// Handle interfaces with a registered type discriminator
+		if handled, err := p.parseDiscriminated(field, fieldData, path); handled {
+			return err
+		}
+
+		// Otherwise an interface{} field takes its single value's inferred type
+		if value, exists := fieldData[fieldName]; exists && field.NumMethod() == 0 {
+			return p.setInferredValue(field, value, tag, path)
+		}
+
+	case reflect.Ptr:
+		// Handle pointers to interfaces, allocated only once the discriminator decodes a value
+		if field.Type().Elem().Kind() == reflect.Interface {
+			target := reflect.New(field.Type().Elem())
+			_, err := p.parseDiscriminated(target.Elem(), fieldData, path)
+			if !target.Elem().IsNil() {
+				field.Set(target)
+			}
+			return err
+		}
 	}
 
 	return nil
 }
 
-// findFieldData finds data that matches a field name (including nested notation)
-func (p *Parser) findFieldData(values url.Values, fieldName string) map[string]string {
+// parseStructFromMap parses a struct from a map of field data. Keys are nested keys
+// relative to the struct, like "name]" or "address][city]", so nested structs,
+// slices and maps recurse to any depth.
+func (p *Parser) parseStructFromMap(fieldData map[string]string, structValue reflect.Value, path string) error {
+	structType := structValue.Type()
+	var errs ValidationErrors
+	var groups oneofGroups
+	var conditions requiredIfFields
+
+	// Under depth-first consumption each key is claimed by exactly one field
+	var owners map[string]int
+	if p.depthFirst {
+		owners = p.nestedOwners(structType, fieldData)
+	}
+
+	for i := 0; i < structValue.NumField(); i++ {
+		field := structValue.Field(i)
+		fieldType := structType.Field(i)
+
+		// Get the form tag or use field name
+		tag, skip := p.parseFormTag(structType, fieldType)
+		if skip || tag.hasOption("raw") {
+			continue
+		}
+		fieldName := tag.name
+
+		// Try to find matching data for this field
+		fieldPath := path + "[" + fieldName + "]"
+		ownData := fieldData
+		if owners != nil {
+			ownData = p.ownedNestedData(fieldData, owners, i)
+		}
+		nestedData := p.findNestedFieldData(ownData, fieldName)
+		if keys := tag.joinedKeys(); keys != nil {
+			nestedData = p.joinFieldData(keys, tag, func(key string) (string, bool) {
+				if value, exists := fieldData[key+"]"]; exists {
+					return value, true
+				}
+				value, exists := fieldData[key]
+				return value, exists
+			})
+		}
+		if _, zipped := tag.structTag.Lookup("zip"); zipped {
+			zipData, err := p.zipFieldData(tag, fieldPath, func(key string) []string {
+				if value, exists := fieldData[key+"]"]; exists {
+					return []string{value}
+				}
+				return nil
+			})
+			if err != nil {
+				if p.recoverField(&errs, tag, fieldPath, err) {
+					continue
+				}
+				return err
+			}
+			nestedData = zipData
+		}
+		if group := fieldType.Tag.Get("oneof"); group != "" {
+			groups.add(group, fieldPath, nestedData != nil)
+		}
+		p.recordField(nestedData != nil)
+		mark := p.markTrace(len(errs))
+		if nestedData == nil {
+			if tag.presence() && field.Kind() == reflect.Bool {
+				field.SetBool(false)
+			} else if tag.required() {
+				errs = append(errs, p.fieldError(tag, fieldPath, "is required", ErrRequired))
+			} else if _, conditional := tag.structTag.Lookup("requiredIf"); conditional {
+				conditions.add(tag, fieldPath)
+			}
+			p.traceField(mark, structType, fieldType, tag, fieldPath, nestedData, errs)
+			continue
+		}
+
+		// Read-only fields are set server-side and must not come from the form
+		if tag.hasOption("readonly") {
+			if !p.skipReadonly {
+				errs = append(errs, p.fieldError(tag, fieldPath, "is read-only", ErrReadOnly))
+			}
+			p.traceField(mark, structType, fieldType, tag, fieldPath, nestedData, errs)
+			continue
+		}
+
+		// Parse the field value
+		start, typeStart := len(errs), p.typeErrorCount()
+		if err := collectFieldErrors(&errs, p.parseFieldValue(field, nestedData, tag, fieldPath)); err != nil {
+			if p.recoverField(&errs, tag, fieldPath, err) {
+				p.traceField(mark, structType, fieldType, tag, fieldPath, nestedData, errs)
+				continue
+			}
+			if msg := tag.message(); msg != "" {
+				return FieldError{Field: fieldPath, Message: msg, Err: err}
+			}
+			return fmt.Errorf("failed to parse field %s: %w", fieldPath, err)
+		}
+		p.applyFieldMessage(errs[start:], tag, fieldPath)
+		if p.typeErrors != nil {
+			p.applyFieldMessage((*p.typeErrors)[typeStart:], tag, fieldPath)
+		}
+		fieldErrs, err := p.validateField(field, tag, fieldPath)
+		if err != nil {
+			if p.recoverField(&errs, tag, fieldPath, err) {
+				p.traceField(mark, structType, fieldType, tag, fieldPath, nestedData, errs)
+				continue
+			}
+			return err
+		}
+		errs = append(errs, fieldErrs...)
+		p.zeroIgnored(field, fieldPath, p.ignoreErrors(&errs, start))
+		p.traceField(mark, structType, fieldType, tag, fieldPath, nestedData, errs)
+	}
+
+	conditionErrs, err := p.checkRequiredIf(structValue, conditions)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, conditionErrs...)
+	errs = append(errs, groups.validate()...)
+	p.ignoreErrors(&errs, 0)
+	return errs.errOrNil()
+}
+
+// joinFieldData builds the field data of a composite field by joining the values of
+// its source keys with the join tag. Missing and empty components are skipped, and
+// the field has no data when none are present.
+func (p *Parser) joinFieldData(keys []string, tag formTag, lookup func(key string) (string, bool)) map[string]string {
+	var parts []string
+	for _, key := range keys {
+		if value, exists := lookup(key); exists && value != "" {
+			parts = append(parts, value)
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	return map[string]string{tag.name: strings.Join(parts, tag.structTag.Get("join"))}
+}
+
+// findNestedFieldData finds the data for fieldName among nested keys, in the same
+// shape findFieldData produces: the field's own value keyed by fieldName and
+// deeper values keyed by their remaining nested key
+func (p *Parser) findNestedFieldData(fieldData map[string]string, fieldName string) map[string]string {
 	result := make(map[string]string)
 
-	// Look for exact matches and nested matches
-	for key, valueSlice := range values {
-		if len(valueSlice) == 0 {
+	for key, value := range fieldData {
+		if key == fieldName {
+			result[fieldName] = value
 			continue
 		}
 
-		if key == fieldName {
-			result[key] = valueSlice[0]
-		} else if strings.HasPrefix(key, fieldName+"[") {
-			// Extract nested part - keep the full nested key with brackets
-			nestedKey := key[len(fieldName)+1:] // Remove fieldName[ but keep the rest
-			result[nestedKey] = valueSlice[0]
+		segment, rest, ok := p.splitKeySegment(key)
+		if !ok || segment != fieldName {
+			continue
+		}
+
+		if rest == "" {
+			result[fieldName] = value
+		} else {
+			result[rest] = value
 		}
 	}
 
@@ -126,183 +1290,351 @@ func (p *Parser) findFieldData(values url.Values, fieldName string) map[string]s
 	return result
 }
 
-// parseFieldValue parses a single field value
-func (p *Parser) parseFieldValue(field reflect.Value, fieldData map[string]string, fieldName string) error {
-	// Handle different field types
-	switch field.Kind() {
-	case reflect.String:
-		for _, value := range fieldData {
-			field.SetString(value)
-			return nil
+// parseSlice parses slice and fixed-size array fields
+func (p *Parser) parseSlice(field reflect.Value, fieldData map[string]string, path string) error {
+	// Group data by index
+	indexedData := make(map[int]map[string]string)
+	keyedData := make(map[string]map[string]string)
+	var errs ValidationErrors
+
+	for key, value := range fieldData {
+		// Extract index from key like "0][subfield]"
+		segment, rest, ok := p.splitKeySegment(key)
+		if !ok {
+			continue
 		}
 
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		for _, value := range fieldData {
-			if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
-				field.SetInt(intVal)
-				return nil
+		index, isIndex := p.arrayIndex(segment)
+		switch {
+		case isIndex:
+			if indexedData[index] == nil {
+				indexedData[index] = make(map[string]string)
+			}
+			p.addElementData(indexedData[index], rest, value)
+		case p.keyedSlices:
+			if keyedData[segment] == nil {
+				keyedData[segment] = make(map[string]string)
 			}
+			p.addElementData(keyedData[segment], rest, value)
 		}
+	}
 
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		for _, value := range fieldData {
-			if uintVal, err := strconv.ParseUint(value, 10, 64); err == nil {
-				field.SetUint(uintVal)
-				return nil
-			}
+	// Under ParseFormAppend the submitted elements follow the existing ones
+	if p.appendSlices && field.Kind() == reflect.Slice && field.Len() > 0 {
+		shifted := make(map[int]map[string]string, len(indexedData))
+		for index, data := range indexedData {
+			shifted[field.Len()+index] = data
 		}
+		indexedData = shifted
+	}
 
-	case reflect.Float32, reflect.Float64:
-		for _, value := range fieldData {
-			if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
-				field.SetFloat(floatVal)
-				return nil
+	// Keyed elements follow the numerically indexed ones in appearance order
+	if len(keyedData) > 0 {
+		next := 0
+		if p.merge && field.Kind() == reflect.Slice {
+			next = field.Len()
+		}
+		for index := range indexedData {
+			if index >= next {
+				next = index + 1
 			}
 		}
+		for _, segment := range p.orderKeyedSegments(keyedData, fieldData, path) {
+			indexedData[next] = keyedData[segment]
+			next++
+		}
+	}
+
+	if len(indexedData) == 0 {
+		return nil
+	}
+
+	// Apply the index overflow policy to indices beyond the destination's capacity
+	errs = append(errs, p.applyIndexOverflow(field, indexedData, path)...)
+	if len(indexedData) == 0 {
+		return errs.errOrNil()
+	}
+
+	// Find the maximum index to determine slice length
+	maxIndex := -1
+	for index := range indexedData {
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
 
-	case reflect.Bool:
-		for _, value := range fieldData {
-			if boolVal, err := strconv.ParseBool(value); err == nil {
-				field.SetBool(boolVal)
-				return nil
+	// Fill a fresh slice with maxIndex + 1 elements, or a fresh fixed-size array.
+	// When merging, existing elements are copied in first and the slice never shrinks.
+	container := reflect.New(field.Type()).Elem()
+	if field.Kind() == reflect.Slice {
+		length := maxIndex + 1
+		if p.merge && field.Len() > length {
+			length = field.Len()
+		}
+		container = reflect.MakeSlice(field.Type(), length, length)
+	}
+	if p.merge {
+		reflect.Copy(container, field)
+	}
+	elemType := field.Type().Elem()
+
+	// Parse each element
+	for index, data := range indexedData {
+		elem := container.Index(index)
+
+		// Elements sent as the WithNullMarker value are left nil
+		if p.isNullElement(data) {
+			if fieldErr, ok := p.setNullElement(elem, fmt.Sprintf("%s[%d]", path, index)); !ok {
+				errs = append(errs, fieldErr)
 			}
+			continue
 		}
 
-	case reflect.Struct:
-		// Handle nested structs
-		if field.CanSet() {
-			// Create a new instance of the struct type
-			newStruct := reflect.New(field.Type()).Elem()
-			if err := p.parseStructFromMap(fieldData, newStruct); err == nil {
-				field.Set(newStruct)
-				return nil
+		switch elemType.Kind() {
+		case reflect.Struct:
+			newElem := reflect.New(elemType).Elem()
+			if p.merge {
+				newElem.Set(elem)
+			}
+			elemPath := fmt.Sprintf("%s[%d]", path, index)
+			if err := collectFieldErrors(&errs, p.parseStructFromMap(data, newElem, elemPath)); err == nil {
+				elem.Set(newElem)
+			}
+		case reflect.Ptr:
+			// Pointer elements like []*Tag point at a newly allocated struct each
+			if elemType.Elem().Kind() != reflect.Struct {
+				break
+			}
+			newStruct := reflect.New(elemType.Elem())
+			if p.merge && !elem.IsNil() {
+				newStruct.Elem().Set(elem.Elem())
+			}
+			elemPath := fmt.Sprintf("%s[%d]", path, index)
+			if err := collectFieldErrors(&errs, p.parseStructFromMap(data, newStruct.Elem(), elemPath)); err == nil {
+				elem.Set(newStruct)
+			}
+		case reflect.String:
+			if value, exists := data["value"]; exists {
+				elem.SetString(value)
+			}
+		case reflect.Interface:
+			// Resolve each element's concrete type through the slice's discriminator
+			if discriminator, registered := p.discriminators[path]; registered {
+				elemPath := fmt.Sprintf("%s[%d]", path, index)
+				if err := collectFieldErrors(&errs, p.decodeDiscriminated(elem, data, elemPath, discriminator)); err != nil {
+					return err
+				}
+			} else if elemType.NumMethod() == 0 {
+				// Otherwise interface{} elements take inferred values, like FormToMap's
+				if inferred := p.inferElement(data); inferred != nil {
+					elem.Set(reflect.ValueOf(inferred))
+				}
+			}
+		default:
+			// Numeric and bool elements convert like map values
+			if value, exists := data["value"]; exists {
+				_ = p.setValue(elem, value, fmt.Sprintf("%s[%d]", path, index))
 			}
 		}
+	}
 
-	case reflect.Slice:
-		// Handle slices
-		return p.parseSlice(field, fieldData)
+	field.Set(container)
 
-	case reflect.Map:
-		// Handle maps
-		return p.parseMap(field, fieldData, fieldName)
+	return errs.errOrNil()
+}
+
+// isRepeatableType reports whether a plain key sent more than once fills a field of
+// type t with every value rather than the one the repeated key policy picks
+func (p *Parser) isRepeatableType(t reflect.Type) bool {
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// isNullElement reports whether an element's data is only the WithNullMarker value
+func (p *Parser) isNullElement(data map[string]string) bool {
+	value, exists := data["value"]
+	return p.nullMarker != "" && exists && len(data) == 1 && value == p.nullMarker
+}
+
+// setNullElement sets a null element to nil. Elements of other types are reported as
+// field errors, or set to their zero value under WithNullAsZero.
+func (p *Parser) setNullElement(elem reflect.Value, path string) (FieldError, bool) {
+	switch elem.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+	default:
+		if !p.nullAsZero {
+			return FieldError{Field: path, Message: "cannot be null", Err: ErrInvalidValue}, false
+		}
 	}
+	elem.Set(reflect.Zero(elem.Type()))
+	return FieldError{}, true
+}
 
-	return nil
+// splitValue splits a single value on the field's sep tag, or else on the
+// WithArrayDelimiter delimiter, keeping it whole when neither is set
+func (p *Parser) splitValue(value string, tag formTag) []string {
+	sep, tagged := tag.structTag.Lookup("sep")
+	if !tagged {
+		sep = p.arrayDelimiter
+	}
+
+	if sep == "" {
+		return []string{value}
+	}
+	return strings.Split(value, sep)
 }
 
-// parseStructFromMap parses a struct from a map of field data
-func (p *Parser) parseStructFromMap(fieldData map[string]string, structValue reflect.Value) error {
-	structType := structValue.Type()
+// splitSliceValue turns a single value for a slice or array field, like tags=a,b,c,
+// into indexed element data. The field's sep tag takes precedence over
+// WithArrayDelimiter; with no delimiter, or sep:"", the value becomes one element.
+// Elements given explicitly by index are kept.
+func (p *Parser) splitSliceValue(fieldData map[string]string, tag formTag) map[string]string {
+	value, exists := fieldData[tag.name]
+	if !exists {
+		return fieldData
+	}
+	return p.splitSliceValues(fieldData, []string{value}, tag)
+}
 
-	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Field(i)
-		fieldType := structType.Field(i)
+// splitSliceValues is splitSliceValue for every value of a plain key, so a key sent
+// more than once, like tags=a&tags=b,c, fills the elements in order: a, b and c
+func (p *Parser) splitSliceValues(fieldData map[string]string, values []string, tag formTag) map[string]string {
+	var parts []string
+	for _, value := range values {
+		parts = append(parts, p.splitValue(value, tag)...)
+	}
 
-		// Get the form tag or use field name
-		formTag := fieldType.Tag.Get("form")
-		fieldName := fieldType.Name
-		if formTag != "" {
-			fieldName = formTag
+	split := make(map[string]string, len(fieldData)+len(parts))
+	for key, data := range fieldData {
+		if key != tag.name {
+			split[key] = data
+		}
+	}
+	for i, part := range parts {
+		key := strconv.Itoa(i) + "]"
+		if _, taken := split[key]; !taken {
+			split[key] = part
 		}
+	}
+	return split
+}
 
-		// Try to find matching data for this field
-		if value, exists := fieldData[fieldName]; exists {
-			if err := p.setValue(field, value); err != nil {
-				continue
-			}
+// applyIndexOverflow drops indices beyond the capacity of a fixed-size array, or of a
+// slice capped by WithMaxSliceLength, reporting them when the overflow policy says so
+func (p *Parser) applyIndexOverflow(field reflect.Value, indexedData map[int]map[string]string, path string) ValidationErrors {
+	limit := p.maxSliceLength
+	if field.Kind() == reflect.Array {
+		limit = field.Len()
+	} else if limit <= 0 || p.indexOverflow == IndexOverflowGrow {
+		return nil
+	}
+
+	var overflow []int
+	for index := range indexedData {
+		if index >= limit {
+			overflow = append(overflow, index)
 		}
 	}
+	sort.Ints(overflow)
+
+	var errs ValidationErrors
+	for _, index := range overflow {
+		if p.indexOverflow == IndexOverflowError {
+			errs = append(errs, FieldError{
+				Field:   fmt.Sprintf("%s[%d]", path, index),
+				Message: fmt.Sprintf("index exceeds maximum length %d", limit),
+				Err:     ErrIndexOverflow,
+			})
+		}
+		delete(indexedData, index)
+	}
 
-	return nil
+	return errs
 }
 
-// parseSlice parses slice fields
-func (p *Parser) parseSlice(field reflect.Value, fieldData map[string]string) error {
-	// Group data by index
-	indexedData := make(map[int]map[string]string)
+// addElementData records a value for a slice or map element, keyed by its nested path
+func (p *Parser) addElementData(elemData map[string]string, rest, value string) {
+	if rest != "" {
+		elemData[rest] = value
+	} else {
+		elemData["value"] = value
+	}
+}
 
-	for key, value := range fieldData {
-		if strings.Contains(key, "[") && strings.Contains(key, "]") {
-			// Extract index from key like "field[0][subfield]"
-			parts := strings.Split(key, "[")
-			if len(parts) >= 2 {
-				indexStr := strings.TrimSuffix(parts[1], "]")
-				if index, err := strconv.Atoi(indexStr); err == nil {
-					if indexedData[index] == nil {
-						indexedData[index] = make(map[string]string)
-					}
-
-					// Reconstruct the nested key
-					nestedKey := strings.Join(parts[2:], "[")
-					if nestedKey != "" {
-						indexedData[index][nestedKey] = value
-					} else {
-						indexedData[index]["value"] = value
-					}
-				}
-			}
+// orderKeyedSegments orders non-numeric slice keys by where they first appear in the form data.
+// Keys are sorted lexically when the appearance order is unknown.
+func (p *Parser) orderKeyedSegments(keyedData map[string]map[string]string, fieldData map[string]string, path string) []string {
+	positions := make(map[string]int, len(keyedData))
+	for key := range fieldData {
+		segment, _, ok := p.splitKeySegment(key)
+		if !ok || keyedData[segment] == nil {
+			continue
+		}
+
+		position, known := p.keyOrder[path+"["+key]
+		if !known {
+			continue
+		}
+		if current, seen := positions[segment]; !seen || position < current {
+			positions[segment] = position
 		}
 	}
 
-	// Create slice with appropriate length
-	if len(indexedData) > 0 {
-		sliceType := field.Type()
-		elemType := sliceType.Elem()
+	segments := make([]string, 0, len(keyedData))
+	for segment := range keyedData {
+		segments = append(segments, segment)
+	}
 
-		// Find the maximum index to determine slice length
-		maxIndex := 0
-		for index := range indexedData {
-			if index > maxIndex {
-				maxIndex = index
-			}
+	sort.Slice(segments, func(i, j int) bool {
+		pi, iKnown := positions[segments[i]]
+		pj, jKnown := positions[segments[j]]
+		if iKnown && jKnown && pi != pj {
+			return pi < pj
+		}
+		if iKnown != jKnown {
+			return iKnown
 		}
+		return segments[i] < segments[j]
+	})
 
-		// Create slice with maxIndex + 1 elements
-		slice := reflect.MakeSlice(sliceType, maxIndex+1, maxIndex+1)
+	return segments
+}
 
-		// Parse each element
-		for index, data := range indexedData {
-			if index < slice.Len() {
-				elem := slice.Index(index)
-
-				switch elemType.Kind() {
-				case reflect.Struct:
-					newElem := reflect.New(elemType).Elem()
-					if err := p.parseStructFromMap(data, newElem); err == nil {
-						elem.Set(newElem)
-					}
-				case reflect.String:
-					if value, exists := data["value"]; exists {
-						elem.SetString(value)
-					}
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					if value, exists := data["value"]; exists {
-						if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
-							elem.SetInt(intVal)
-						}
-					}
-				}
-			}
-		}
+// splitKeySegment splits a nested key like "0][name]" into its first segment ("0")
+// and the remaining nested key ("name]")
+func (p *Parser) splitKeySegment(key string) (string, string, bool) {
+	end := strings.Index(key, "]")
+	if end < 0 {
+		return "", "", false
+	}
 
-		field.Set(slice)
+	segment, rest := key[:end], key[end+1:]
+	if rest == "" {
+		return segment, "", true
+	}
+	if !strings.HasPrefix(rest, "[") {
+		return "", "", false
 	}
 
-	return nil
+	return segment, rest[1:], true
 }
 
 // parseMap parses map fields
-func (p *Parser) parseMap(field reflect.Value, fieldData map[string]string, fieldName string) error {
+func (p *Parser) parseMap(field reflect.Value, fieldData map[string]string, path string) error {
 	// Group data by map key
-	mapData := make(map[string]string)
+	mapData := make(map[string]map[string]string)
+	var errs ValidationErrors
 
 	for key, value := range fieldData {
-		if strings.HasPrefix(key, fieldName+"[") && strings.HasSuffix(key, "]") {
-			// Extract map key from "field[key]"
-			mapKey := key[len(fieldName)+1 : len(key)-1]
-			mapData[mapKey] = value
+		// Extract map key from "key]" or "key][nested]"
+		mapKey, rest, ok := p.splitKeySegment(key)
+		if !ok {
+			continue
+		}
+
+		if mapData[mapKey] == nil {
+			mapData[mapKey] = make(map[string]string)
 		}
+		p.addElementData(mapData[mapKey], rest, value)
 	}
 
 	// Create map and populate it
@@ -312,17 +1644,27 @@ func (p *Parser) parseMap(field reflect.Value, fieldData map[string]string, fiel
 		elemType := mapType.Elem()
 
 		newMap := reflect.MakeMap(mapType)
+		if p.merge {
+			iter := field.MapRange()
+			for iter.Next() {
+				newMap.SetMapIndex(iter.Key(), iter.Value())
+			}
+		}
 
-		for keyStr, valueStr := range mapData {
-			// Parse key
+		for keyStr, data := range mapData {
+			// Parse key; keys that do not convert to the key type, like "x" for
+			// a map[int]T, are skipped rather than colliding on the zero key
 			keyValue := reflect.New(keyType).Elem()
-			if err := p.setValue(keyValue, keyStr); err != nil {
+			if err := p.setValue(keyValue, keyStr, path+"["+keyStr+"]"); err != nil {
 				continue
 			}
 
-			// Parse value
+			// Parse value, starting from the existing entry when merging
 			elemValue := reflect.New(elemType).Elem()
-			if err := p.setValue(elemValue, valueStr); err != nil {
+			if existing := newMap.MapIndex(keyValue); p.merge && existing.IsValid() {
+				elemValue.Set(existing)
+			}
+			if !p.parseElement(elemValue, data, path+"["+keyStr+"]", &errs) {
 				continue
 			}
 
@@ -332,29 +1674,182 @@ func (p *Parser) parseMap(field reflect.Value, fieldData map[string]string, fiel
 		field.Set(newMap)
 	}
 
-	return nil
+	return errs.errOrNil()
+}
+
+// checkboxSetData turns the plain values of a map[K]bool field, as multi-select
+// checkboxes send them (category=sports&category=tech), into entries set to true,
+// alongside any bracketed entries like category[news]=false
+func (p *Parser) checkboxSetData(fieldData map[string]string, values []string, fieldName string) map[string]string {
+	if len(values) == 0 {
+		return fieldData
+	}
+
+	set := make(map[string]string, len(fieldData)+len(values))
+	for key, value := range fieldData {
+		if key != fieldName {
+			set[key] = value
+		}
+	}
+	for _, value := range values {
+		if value != "" && !strings.ContainsAny(value, "[]") {
+			set[value+"]"] = "true"
+		}
+	}
+	return set
 }
 
-// setValue sets a value to a reflect.Value based on its type
-func (p *Parser) setValue(field reflect.Value, value string) error {
+// invertMapData swaps the map keys and values of a map field's data, so
+// labels[red]=primary becomes the entry primary: red. Only flat entries are
+// swapped; nested keys and values that cannot form a key segment are dropped.
+// When several keys share a value, the lexically last key wins.
+func (p *Parser) invertMapData(fieldData map[string]string) map[string]string {
+	keys := make([]string, 0, len(fieldData))
+	for key := range fieldData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	inverted := make(map[string]string, len(keys))
+	for _, key := range keys {
+		segment, rest, ok := p.splitKeySegment(key)
+		value := fieldData[key]
+		if !ok || rest != "" || value == "" || strings.ContainsAny(value, "[]") {
+			continue
+		}
+		inverted[value+"]"] = segment
+	}
+	return inverted
+}
+
+// inferElement builds the value of an interface{} element from its grouped data the
+// way FormToMap would: a single value is type-inferred and nested keys become maps
+// and slices, keeping an own value beside them under "value"
+func (p *Parser) inferElement(data map[string]string) interface{} {
+	if value, exists := data["value"]; exists && len(data) == 1 {
+		return p.convertValueToType(value)
+	}
+
+	values := make(url.Values, len(data))
+	for key, value := range data {
+		if key == "value" {
+			values.Set("element", value)
+		} else {
+			values.Set("element["+key, value)
+		}
+	}
+	result, err := p.parseFormFlexibly(values)
+	if err != nil {
+		return nil
+	}
+	return result["element"]
+}
+
+// parseElement fills a map element from its grouped data and reports whether it was set.
+// Pointer-to-struct elements are only allocated when nested data populates them, and
+// interface{} elements take inferred values, nested data becoming maps and slices.
+// Field errors from nested structs are added to errs.
+func (p *Parser) parseElement(elem reflect.Value, data map[string]string, path string, errs *ValidationErrors) bool {
+	switch {
+	case elem.Kind() == reflect.Struct:
+		return collectFieldErrors(errs, p.parseStructFromMap(data, elem, path)) == nil
+
+	case elem.Kind() == reflect.Ptr && elem.Type().Elem().Kind() == reflect.Struct:
+		newStruct := reflect.New(elem.Type().Elem())
+		if p.merge && !elem.IsNil() {
+			newStruct.Elem().Set(elem.Elem())
+		}
+		if err := collectFieldErrors(errs, p.parseStructFromMap(data, newStruct.Elem(), path)); err != nil || newStruct.Elem().IsZero() {
+			return false
+		}
+		elem.Set(newStruct)
+		return true
+
+	case elem.Kind() == reflect.Interface && elem.NumMethod() == 0:
+		// interface{} entries take inferred values, with nested data like meta[a][b]=1
+		// in a map[string]interface{} becoming maps and slices as FormToMap builds them
+		inferred := p.inferElement(data)
+		if inferred == nil {
+			return false
+		}
+		elem.Set(reflect.ValueOf(inferred))
+		return true
+
+	default:
+		value, exists := data["value"]
+		if !exists {
+			return false
+		}
+		// Empty numbers add no entry unless they are read as zero
+		if p.handleEmptyNumber(elem, value) {
+			return p.emptyNumberZero || p.lenient
+		}
+		return p.setValue(elem, value, path) == nil
+	}
+}
+
+// setValue sets a value to a reflect.Value based on its type. It reports values that
+// do not convert to, or overflow, the destination type.
+func (p *Parser) setValue(field reflect.Value, value, path string) error {
+	if p.handleEmptyNumber(field, value) {
+		return nil
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
-			field.SetInt(intVal)
+		intVal, err := p.parseIntValue(value, 10)
+		if err != nil && field.Kind() == reflect.Int32 {
+			if r, ok := p.runeValue(value); ok {
+				intVal, err = int64(r), nil
+			}
+		}
+		if enumVal, ok := p.enumValue(field.Type(), value); err != nil && ok {
+			intVal, err = enumVal, nil
+		}
+		if err == nil && field.OverflowInt(intVal) {
+			err = fmt.Errorf("value %q overflows %s", value, field.Type())
 		}
+		if err != nil {
+			p.recordTypeError(field, path, value)
+			return err
+		}
+		field.SetInt(intVal)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if uintVal, err := strconv.ParseUint(value, 10, 64); err == nil {
-			field.SetUint(uintVal)
+		uintVal, err := p.parseUintValue(value, 10)
+		if enumVal, ok := p.enumValue(field.Type(), value); err != nil && ok && enumVal >= 0 {
+			uintVal, err = uint64(enumVal), nil
+		}
+		if err == nil && field.OverflowUint(uintVal) {
+			err = fmt.Errorf("value %q overflows %s", value, field.Type())
 		}
+		if err != nil {
+			p.recordTypeError(field, path, value)
+			return err
+		}
+		field.SetUint(uintVal)
 	case reflect.Float32, reflect.Float64:
-		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
-			field.SetFloat(floatVal)
+		floatVal, err := p.parseFloatValue(value)
+		if err == nil && field.OverflowFloat(floatVal) {
+			err = fmt.Errorf("value %q overflows %s", value, field.Type())
+		}
+		if err != nil {
+			p.recordTypeError(field, path, value)
+			return err
 		}
+		field.SetFloat(floatVal)
 	case reflect.Bool:
-		if boolVal, err := strconv.ParseBool(value); err == nil {
-			field.SetBool(boolVal)
+		boolVal, err := p.parseBoolValue(value)
+		if err != nil {
+			p.recordTypeError(field, path, value)
+			return err
+		}
+		field.SetBool(boolVal)
+	case reflect.Slice:
+		// []byte receives the decoded value as-is
+		if field.Type().Elem().Kind() == reflect.Uint8 {
+			field.SetBytes([]byte(value))
 		}
 	}
 	return nil
@@ -396,30 +1891,49 @@ func ParseFloat(value string) (float64, error) {
 
 // FormToJSON converts form-urlencoded data to JSON dynamically
 func (p *Parser) FormToJSON(formData string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.FormToJSONWriter(formData, &buf); err != nil {
+		return nil, err
+	}
+
+	// Drop the newline the encoder ends the document with
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// FormToJSONBytes converts form-urlencoded data from bytes to JSON
+func (p *Parser) FormToJSONBytes(data []byte) ([]byte, error) {
+	return p.FormToJSON(string(data))
+}
+
+// FormToJSONWriter converts form data to indented JSON and writes it to w. The form
+// is parsed into a map first, as in FormToMap, so the whole document is built in
+// memory before it is encoded; only the encoded bytes skip an intermediate buffer.
+func (p *Parser) FormToJSONWriter(formData string, w io.Writer) error {
 	// Parse the form data
 	values, err := url.ParseQuery(formData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse form data: %w", err)
+		return fmt.Errorf("failed to parse form data: %w", err)
 	}
 
 	// Convert to dynamic JSON structure
-	result := p.parseFormFlexibly(values)
-
-	// Convert to JSON
-	jsonData, err := json.MarshalIndent(result, "", "  ")
+	result, err := p.parseFormFlexibly(values)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal to JSON: %w", err)
+		return err
 	}
 
-	return jsonData, nil
-}
+	// Encode straight to the writer
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to marshal to JSON: %w", err)
+	}
 
-// FormToJSONBytes converts form-urlencoded data from bytes to JSON
-func (p *Parser) FormToJSONBytes(data []byte) ([]byte, error) {
-	return p.FormToJSON(string(data))
+	return nil
 }
 
-// FormToMap converts form-urlencoded data to a map[string]interface{} dynamically
+// FormToMap converts form-urlencoded data to a map[string]interface{} dynamically.
+// Every scalar, top-level or nested, is inferred the same way: an int (int64 where it
+// does not fit), a float64, a bool from the parser's bool vocabulary, or the string.
 func (p *Parser) FormToMap(formData string) (map[string]interface{}, error) {
 	// Parse the form data
 	values, err := url.ParseQuery(formData)
@@ -428,8 +1942,7 @@ func (p *Parser) FormToMap(formData string) (map[string]interface{}, error) {
 	}
 
 	// Convert to dynamic map structure
-	result := p.parseFormFlexibly(values)
-	return result, nil
+	return p.parseFormFlexibly(values)
 }
 
 // FormToMapBytes converts form-urlencoded data from bytes to a map
@@ -437,28 +1950,39 @@ func (p *Parser) FormToMapBytes(data []byte) (map[string]interface{}, error) {
 	return p.FormToMap(string(data))
 }
 
+// ParseFormToSliceMap returns every value of every key exactly as url.ParseQuery
+// decodes it, with no type inference, key restructuring or struct mapping, so
+// tags=a&tags=b&user[name]=x yields {"tags": ["a", "b"], "user[name]": ["x"]}
+func (p *Parser) ParseFormToSliceMap(formData string) (map[string][]string, error) {
+	values, err := url.ParseQuery(formData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse form data: %w", err)
+	}
+	return values, nil
+}
+
 // parseFormFlexibly parses any form data structure dynamically
-func (p *Parser) parseFormFlexibly(values url.Values) map[string]interface{} {
+func (p *Parser) parseFormFlexibly(values url.Values) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 
+	if p.jsonPointerKeys {
+		values = p.rewriteKeys(values, p.jsonPointerKey)
+	}
+	if err := p.checkRepeatedKeys(values); err != nil {
+		return nil, err
+	}
+
 	// Group all keys by their base structure
-	keyGroups := p.groupKeysByStructure(values)
+	keyGroups := p.groupKeysByStructure(p.expandEmptyBrackets(values))
 
 	// Process each group
 	for baseKey, group := range keyGroups {
-		if group.isSimple {
-			// Simple key-value pair
-			result[baseKey] = group.value
-		} else if group.isArray {
-			// Array structure
-			result[baseKey] = p.buildArrayFromGroup(group)
-		} else {
-			// Nested object structure
-			result[baseKey] = p.buildObjectFromGroup(group)
+		if value, ok := p.buildValueFromGroup(group); ok {
+			result[baseKey] = value
 		}
 	}
 
-	return result
+	return result, nil
 }
 
 // groupKeysByStructure groups form keys by their structure
@@ -470,7 +1994,7 @@ func (p *Parser) groupKeysByStructure(values url.Values) map[string]*keyGroup {
 			continue
 		}
 
-		value := valueSlice[0]
+		value := p.leafValue(valueSlice)
 
 		// Parse the key structure
 		parsed := p.parseKeyStructure(key)
@@ -487,14 +2011,13 @@ func (p *Parser) groupKeysByStructure(values url.Values) map[string]*keyGroup {
 		group := groups[parsed.baseKey]
 
 		if parsed.isArray {
-			group.isArray = true
 			p.addToArrayGroup(group, parsed, value)
 		} else if parsed.isNested {
-			group.isObject = true
 			p.addToObjectGroup(group, parsed, value)
 		} else {
 			group.isSimple = true
-			group.value = value
+			group.value = p.convertValueToType(value)
+			group.raw = value
 		}
 	}
 
@@ -507,28 +2030,27 @@ func (p *Parser) parseKeyStructure(key string) *parsedKey {
 		path: make([]string, 0),
 	}
 
-	// Handle simple keys
-	if !strings.Contains(key, "[") && !strings.Contains(key, "]") {
+	// Keys without a base key or a well-formed bracket suffix are plain keys
+	openBracket := strings.Index(key, "[")
+	if openBracket <= 0 || !bracketSuffixPattern.MatchString(key[openBracket:]) {
 		result.baseKey = key
 		return result
 	}
 
 	// Extract base key (everything before first [)
-	openBracket := strings.Index(key, "[")
 	result.baseKey = key[:openBracket]
 
-	// Parse the rest using regex to find all bracket groups
-	re := regexp.MustCompile(`\[([^\]]+)\]`)
-	matches := re.FindAllStringSubmatch(key[openBracket:], -1)
+	// Find all bracket groups
+	matches := bracketGroupPattern.FindAllStringSubmatch(key[openBracket:], -1)
 
 	if len(matches) == 0 {
 		return result
 	}
 
 	// Check if first bracket contains a number (array index)
-	if firstMatch := matches[0][1]; p.isNumeric(firstMatch) {
+	if index, ok := p.arrayIndex(matches[0][1]); ok {
 		result.isArray = true
-		result.arrayIndex, _ = strconv.Atoi(firstMatch)
+		result.arrayIndex = index
 
 		// Add remaining path elements
 		for i := 1; i < len(matches); i++ {
@@ -558,7 +2080,8 @@ func (p *Parser) addToArrayGroup(group *keyGroup, parsed *parsedKey, value strin
 
 	if len(parsed.path) == 0 {
 		// Direct value at this index
-		arrayItem.value = value
+		arrayItem.value = p.convertValueToType(value)
+		arrayItem.raw = value
 		arrayItem.isSimple = true
 	} else {
 		// Nested structure at this index
@@ -570,7 +2093,8 @@ func (p *Parser) addToArrayGroup(group *keyGroup, parsed *parsedKey, value strin
 func (p *Parser) addToObjectGroup(group *keyGroup, parsed *parsedKey, value string) {
 	if len(parsed.path) == 0 {
 		// Direct nested value
-		group.value = value
+		group.value = p.convertValueToType(value)
+		group.raw = value
 		group.isSimple = true
 	} else {
 		// Nested structure
@@ -583,6 +2107,7 @@ func (p *Parser) addNestedToGroup(group *keyGroup, path []string, value string)
 	if len(path) == 0 {
 		// Convert value to proper type before setting
 		group.value = p.convertValueToType(value)
+		group.raw = value
 		group.isSimple = true
 		return
 	}
@@ -591,9 +2116,8 @@ func (p *Parser) addNestedToGroup(group *keyGroup, path []string, value string)
 	remainingPath := path[1:]
 
 	// Check if currentKey is a number (array index)
-	if p.isNumeric(currentKey) {
+	if index, ok := p.arrayIndex(currentKey); ok {
 		// This is an array index
-		index, _ := strconv.Atoi(currentKey)
 
 		// Initialize arrayData map if it doesn't exist
 		if group.arrayData == nil {
@@ -607,7 +2131,6 @@ func (p *Parser) addNestedToGroup(group *keyGroup, path []string, value string)
 				arrayData: make(map[int]*keyGroup),
 			}
 		}
-		group.arrayData[index].isArray = true
 		p.addNestedToGroup(group.arrayData[index], remainingPath, value)
 	} else {
 		// This is a regular key
@@ -628,6 +2151,7 @@ func (p *Parser) addNestedToGroup(group *keyGroup, path []string, value string)
 		if len(remainingPath) == 0 {
 			// This is the final value - convert to proper type
 			child.value = p.convertValueToType(value)
+			child.raw = value
 			child.isSimple = true
 		} else {
 			// Continue nesting
@@ -638,13 +2162,12 @@ func (p *Parser) addNestedToGroup(group *keyGroup, path []string, value string)
 
 // convertValueToType converts string values to their appropriate types
 func (p *Parser) convertValueToType(value string) interface{} {
-	// Try to convert to int
-	if intVal, err := strconv.Atoi(value); err == nil {
-		return intVal
-	}
-
-	// Try to convert to int64
+	// Try to convert to an integer: int when it fits, for callers that expect plain
+	// ints, and int64 beyond that, which only happens where int is 32 bits
 	if int64Val, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if int64Val >= math.MinInt && int64Val <= math.MaxInt {
+			return int(int64Val)
+		}
 		return int64Val
 	}
 
@@ -653,8 +2176,8 @@ func (p *Parser) convertValueToType(value string) interface{} {
 		return floatVal
 	}
 
-	// Try to convert to bool
-	if boolVal, err := strconv.ParseBool(value); err == nil {
+	// Try to convert to bool, with the same vocabulary as struct fields
+	if boolVal, ok := p.boolWord(value); ok {
 		return boolVal
 	}
 
@@ -662,6 +2185,23 @@ func (p *Parser) convertValueToType(value string) interface{} {
 	return value
 }
 
+// buildValueFromGroup builds the value of a key group, shaped like its FormToTree node:
+// a scalar, an array when all of its nested keys are indices, or else an object that
+// keeps its own value under "value" and its indices as keys, like a[0]=x&a[n]=y
+// giving {"0": "x", "n": "y"}. It reports false for groups without data.
+func (p *Parser) buildValueFromGroup(group *keyGroup) (interface{}, bool) {
+	hasNested := len(group.children) > 0 || len(group.arrayData) > 0
+	switch {
+	case group.isSimple && !hasNested:
+		return group.value, true
+	case !hasNested:
+		return nil, false
+	case !group.isSimple && len(group.children) == 0:
+		return p.buildArrayFromGroup(group), true
+	}
+	return p.buildObjectFromGroup(group), true
+}
+
 // buildArrayFromGroup builds an array from a key group
 func (p *Parser) buildArrayFromGroup(group *keyGroup) []interface{} {
 	if len(group.arrayData) == 0 {
@@ -679,17 +2219,13 @@ func (p *Parser) buildArrayFromGroup(group *keyGroup) []interface{} {
 	// Create array with proper size
 	result := make([]interface{}, maxIndex+1)
 
-	// Process each index
+	// Process each index, leaving WithNullMarker items nil
 	for index, arrayItem := range group.arrayData {
-		if arrayItem.isSimple {
-			result[index] = arrayItem.value
-		} else if len(arrayItem.children) > 0 || len(arrayItem.arrayData) > 0 {
-			// Check if it has children or array data to determine type
-			if len(arrayItem.arrayData) > 0 {
-				result[index] = p.buildArrayFromGroup(arrayItem)
-			} else {
-				result[index] = p.buildObjectFromGroup(arrayItem)
-			}
+		if arrayItem.isSimple && p.nullMarker != "" && arrayItem.raw == p.nullMarker {
+			continue
+		}
+		if value, ok := p.buildValueFromGroup(arrayItem); ok {
+			result[index] = value
 		}
 	}
 
@@ -707,40 +2243,35 @@ func (p *Parser) buildObjectFromGroup(group *keyGroup) map[string]interface{} {
 
 	// Add nested objects
 	for key, child := range group.children {
-		if child.isSimple {
-			result[key] = child.value
-		} else if len(child.children) > 0 || len(child.arrayData) > 0 {
-			// Check if it has children or array data to determine type
-			if len(child.arrayData) > 0 {
-				result[key] = p.buildArrayFromGroup(child)
-			} else {
-				result[key] = p.buildObjectFromGroup(child)
-			}
+		if value, ok := p.buildValueFromGroup(child); ok {
+			result[key] = value
 		}
 	}
 
 	// Add array data if any - convert int keys to strings
 	for key, child := range group.arrayData {
-		keyStr := fmt.Sprintf("%d", key) // Convert int to string
-		if child.isSimple {
-			result[keyStr] = child.value
-		} else if len(child.children) > 0 || len(child.arrayData) > 0 {
-			// Check if it has children or array data to determine type
-			if len(child.arrayData) > 0 {
-				result[keyStr] = p.buildArrayFromGroup(child)
-			} else {
-				result[keyStr] = p.buildObjectFromGroup(child)
-			}
+		if value, ok := p.buildValueFromGroup(child); ok {
+			result[strconv.Itoa(key)] = value
 		}
 	}
 
 	return result
 }
 
-// isNumeric checks if a string represents a number
-func (p *Parser) isNumeric(s string) bool {
-	_, err := strconv.Atoi(s)
-	return err == nil
+// arrayIndex reports whether s is a plain non-negative array index within maxArrayIndex.
+// Indices with leading zeros, like 00 or 01, are keys rather than aliases of 0 and 1,
+// so two spellings of one index never compete for the same element.
+func (p *Parser) arrayIndex(s string) (int, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" || len(s) > 1 && s[0] == '0' {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(s)
+	if err != nil || index > maxArrayIndex {
+		return 0, false
+	}
+
+	return index, true
 }
 
 // FormToJSONEncoded converts URL-encoded form data with Unicode escapes to JSON
@@ -749,7 +2280,7 @@ func (p *Parser) FormToJSONEncoded(encodedData string) ([]byte, error) {
 	unescapedData := p.unescapeUnicode(encodedData)
 
 	// Then URL decode the data
-	decodedData, err := url.QueryUnescape(unescapedData) // Use unescapedData here
+	decodedData, err := p.urlUnescape(unescapedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to URL decode data: %w", err)
 	}
@@ -798,7 +2329,7 @@ func (p *Parser) FormToMapEncoded(encodedData string) (map[string]interface{}, e
 	unescapedData := p.unescapeUnicode(encodedData)
 
 	// Then URL decode the data
-	decodedData, err := url.QueryUnescape(unescapedData)
+	decodedData, err := p.urlUnescape(unescapedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to URL decode data: %w", err)
 	}
@@ -807,6 +2338,21 @@ func (p *Parser) FormToMapEncoded(encodedData string) (map[string]interface{}, e
 	return p.FormToMap(decodedData)
 }
 
+// urlUnescape URL-decodes data according to the configured unescape mode.
+// In UnescapePath mode literal "+" characters are re-escaped so the later
+// query parsing step keeps them instead of turning them into spaces.
+func (p *Parser) urlUnescape(data string) (string, error) {
+	if p.unescapeMode != UnescapePath {
+		return url.QueryUnescape(data)
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(decoded, "+", "%2B"), nil
+}
+
 // FormToMapEncodedBytes converts URL-encoded form data from bytes to a map
 func (p *Parser) FormToMapEncodedBytes(data []byte) (map[string]interface{}, error) {
 	return p.FormToMapEncoded(string(data))
@@ -0,0 +1,62 @@
+package parseform
+
+import (
+	"testing"
+)
+
+type fuzzForm struct {
+	Name    string            `form:"name"`
+	Age     int               `form:"age"`
+	Tags    []string          `form:"tags"`
+	Items   []fuzzItem        `form:"items"`
+	Meta    map[string]string `form:"meta"`
+	Account struct {
+		ID    int      `form:"id"`
+		Users []string `form:"users"`
+	} `form:"account"`
+}
+
+type fuzzItem struct {
+	ID   int    `form:"id"`
+	Name string `form:"name"`
+}
+
+func FuzzParseForm(f *testing.F) {
+	seeds := []string{
+		// Nested keys
+		"account[id]=1&account[users][0]=a",
+		"leads[status][0][tags][0][name]=hot",
+		"meta[a][b][c]=x",
+		// Indexed keys
+		"items[0][id]=1&items[1][name]=b",
+		"tags[]=a&tags[]=b",
+		"items[99999999999][id]=1",
+		"items[-1][id]=1",
+		// Malformed keys
+		"[",
+		"][",
+		"a[",
+		"a]b",
+		"[]=x",
+		"[a]=x",
+		"a[b=1",
+		"a[b]c=1",
+		"a[[b]]=1",
+		"a[]]=1",
+		"=&&=",
+		"%zz=1",
+		"name=%",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	parser := NewParser(WithKeyedSlices(), WithDottedKeys())
+	f.Fuzz(func(t *testing.T, formData string) {
+		var target fuzzForm
+		_ = parser.ParseForm(formData, &target)
+		_, _ = parser.FormToMap(formData)
+		_, _ = parser.FormToJSON(formData)
+		_, _ = parser.FormToTree(formData)
+	})
+}
@@ -0,0 +1,691 @@
+package parseform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestIndexedScalarSliceElements(t *testing.T) {
+	type form struct {
+		Flags  []bool    `form:"flags"`
+		Counts []uint    `form:"counts"`
+		Prices []float64 `form:"prices"`
+		Pair   [2]bool   `form:"pair"`
+	}
+
+	var got form
+	formData := "flags[0]=true&flags[1]=false&flags[2]=1&counts[0]=3&counts[1]=7&prices[0]=1.5&prices[1]=2&pair[1]=true"
+	if err := NewParser().ParseForm(formData, &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+
+	want := form{
+		Flags:  []bool{true, false, true},
+		Counts: []uint{3, 7},
+		Prices: []float64{1.5, 2},
+		Pair:   [2]bool{false, true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseForm() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIndexedScalarSliceElementErrors(t *testing.T) {
+	var got struct {
+		Flags []bool `form:"flags"`
+	}
+	err := NewParser(WithStrictMode()).ParseForm("flags[0]=true&flags[1]=maybe", &got)
+
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Field != "flags[1]" {
+		t.Fatalf("ParseForm() error = %v, want a flags[1] error", err)
+	}
+}
+
+func TestSliceDelimiterPrecedence(t *testing.T) {
+	type form struct {
+		Plain []string `form:"plain"`
+		Piped []string `form:"piped" sep:"|"`
+		Whole []string `form:"whole" sep:""`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		opts     []Option
+		want     form
+	}{
+		{
+			name:     "no delimiter keeps the value whole",
+			formData: "plain=a~b&piped=a|b&whole=a~b",
+			want:     form{Plain: []string{"a~b"}, Piped: []string{"a", "b"}, Whole: []string{"a~b"}},
+		},
+		{
+			name:     "sep tag takes precedence over the delimiter",
+			formData: "plain=a~b&piped=a|b~c&whole=a~b",
+			opts:     []Option{WithArrayDelimiter("~")},
+			want:     form{Plain: []string{"a", "b"}, Piped: []string{"a", "b~c"}, Whole: []string{"a~b"}},
+		},
+		{
+			name:     "indexed keys are kept over split parts",
+			formData: "plain=a~b&plain[1]=x&piped=a|b&piped[2]=z",
+			opts:     []Option{WithArrayDelimiter("~")},
+			want:     form{Plain: []string{"a", "x"}, Piped: []string{"a", "b", "z"}},
+		},
+		{
+			name:     "indexed keys alone ignore delimiters",
+			formData: "plain[0]=a~b&piped[0]=a|b",
+			opts:     []Option{WithArrayDelimiter("~")},
+			want:     form{Plain: []string{"a~b"}, Piped: []string{"a|b"}},
+		},
+		{
+			name:     "repeated plain keys are each split",
+			formData: "plain=a~b&plain=c&piped=a|b&piped=c&whole=a~b&whole=c",
+			opts:     []Option{WithArrayDelimiter("~")},
+			want:     form{Plain: []string{"a", "b", "c"}, Piped: []string{"a", "b", "c"}, Whole: []string{"a~b", "c"}},
+		},
+		{
+			name:     "repeated plain keys beside indexed keys",
+			formData: "plain=a&plain=b&plain[0]=x",
+			want:     form{Plain: []string{"x", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser(tt.opts...).ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepeatedPlainKeyIntoSlice(t *testing.T) {
+	type form struct {
+		Nums  []int  `form:"nums"`
+		Pair  [2]int `form:"pair"`
+		Count int    `form:"count"`
+	}
+
+	tests := []struct {
+		name    string
+		policy  RepeatedKeyPolicy
+		want    form
+		wantErr bool
+	}{
+		{name: "first", policy: RepeatedKeyFirst, want: form{Nums: []int{1, 2}, Pair: [2]int{3, 4}, Count: 5}},
+		{name: "last", policy: RepeatedKeyLast, want: form{Nums: []int{1, 2}, Pair: [2]int{3, 4}, Count: 6}},
+		{name: "error", policy: RepeatedKeyError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(WithRepeatedKeyPolicy(tt.policy)).ParseForm("nums=1&nums=2&pair=3&pair=4&pair=5&count=5&count=6", &got)
+			if tt.wantErr {
+				errs, ok := err.(ValidationErrors)
+				if !ok || len(errs) != 3 || !errors.Is(errs[0], ErrRepeatedKey) {
+					t.Fatalf("ParseForm() error = %v, want three repeated key errors", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormToJSONMatchesWriter(t *testing.T) {
+	parser := NewParser()
+	formData := "name=John&age=25&tags[0]=a&user[flag]=true"
+
+	got, err := parser.FormToJSON(formData)
+	if err != nil {
+		t.Fatalf("FormToJSON() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := parser.FormToJSONWriter(formData, &buf); err != nil {
+		t.Fatalf("FormToJSONWriter() error = %v", err)
+	}
+	if want := strings.TrimSuffix(buf.String(), "\n"); string(got) != want {
+		t.Errorf("FormToJSON() = %s, FormToJSONWriter() = %s", got, want)
+	}
+
+	m, err := parser.FormToMap(formData)
+	if err != nil {
+		t.Fatalf("FormToMap() error = %v", err)
+	}
+	want, _ := json.MarshalIndent(m, "", "  ")
+	if !bytes.Equal(got, want) {
+		t.Errorf("FormToJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestFormToJSONReportsParseErrors(t *testing.T) {
+	if _, err := NewParser().FormToJSON("%zz=1"); err == nil {
+		t.Error("FormToJSON() error = nil, want a parse error")
+	}
+}
+
+func TestDeepStructSliceNesting(t *testing.T) {
+	type tag struct {
+		Name string `form:"name"`
+	}
+	type status struct {
+		ID   int    `form:"id"`
+		Tags []tag  `form:"tags"`
+		Ptrs []*tag `form:"ptrs"`
+	}
+	type form struct {
+		Leads struct {
+			Status []status `form:"status"`
+		} `form:"leads"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		want     []status
+	}{
+		{
+			name:     "two tags in one status",
+			formData: "leads[status][0][tags][0][name]=hot&leads[status][0][tags][1][name]=cold",
+			want:     []status{{Tags: []tag{{"hot"}, {"cold"}}}},
+		},
+		{
+			name:     "several statuses with gaps",
+			formData: "leads[status][0][id]=1&leads[status][0][tags][2][name]=c&leads[status][1][id]=2&leads[status][1][tags][0][name]=a",
+			want:     []status{{ID: 1, Tags: []tag{{}, {}, {"c"}}}, {ID: 2, Tags: []tag{{"a"}}}},
+		},
+		{
+			name:     "pointer elements",
+			formData: "leads[status][0][ptrs][1][name]=p",
+			want:     []status{{Ptrs: []*tag{nil, {"p"}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser().ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Leads.Status, tt.want) {
+				t.Errorf("Leads.Status = %+v, want %+v", got.Leads.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeepNestingErrorPaths(t *testing.T) {
+	var got struct {
+		Leads struct {
+			Status []struct {
+				Tags []struct {
+					Count int `form:"count"`
+				} `form:"tags"`
+			} `form:"status"`
+		} `form:"leads"`
+	}
+	err := NewParser(WithStrictMode()).ParseForm("leads[status][1][tags][2][count]=abc", &got)
+
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Field != "leads[status][1][tags][2][count]" {
+		t.Fatalf("ParseForm() error = %v, want a leads[status][1][tags][2][count] error", err)
+	}
+}
+
+func TestIntKeyedMaps(t *testing.T) {
+	type item struct {
+		Name string `form:"name"`
+		Qty  int    `form:"qty"`
+	}
+	type form struct {
+		Items map[int]item    `form:"items"`
+		Small map[int8]int    `form:"small"`
+		Bytes map[string]int8 `form:"bytes"`
+	}
+
+	tests := []struct {
+		name       string
+		formData   string
+		opts       []Option
+		want       form
+		wantFields []string
+	}{
+		{
+			name:     "struct values by int key",
+			formData: "items[1][name]=a&items[1][qty]=2&items[-3][name]=b",
+			want:     form{Items: map[int]item{1: {"a", 2}, -3: {Name: "b"}}},
+		},
+		{
+			name:     "invalid int key is skipped",
+			formData: "items[x][name]=a&items[2][name]=b",
+			want:     form{Items: map[int]item{2: {Name: "b"}}},
+		},
+		{
+			name:       "invalid int key is reported under strict mode",
+			formData:   "items[x][name]=a&items[2][name]=b",
+			opts:       []Option{WithStrictMode()},
+			want:       form{Items: map[int]item{2: {Name: "b"}}},
+			wantFields: []string{"items[x]"},
+		},
+		{
+			name:       "overflowing key and value are reported",
+			formData:   "small[300]=1&small[7]=8&bytes[a]=1000&bytes[b]=5",
+			opts:       []Option{WithStrictMode()},
+			want:       form{Small: map[int8]int{7: 8}, Bytes: map[string]int8{"b": 5}},
+			wantFields: []string{"bytes[a]", "small[300]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(tt.opts...).ParseForm(tt.formData, &got)
+
+			var fields []string
+			if errs, ok := err.(ValidationErrors); ok {
+				for _, fieldErr := range errs {
+					fields = append(fields, fieldErr.Field)
+				}
+				sort.Strings(fields)
+			} else if err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("ParseForm() error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetValueReportsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  interface{}
+		value   string
+		wantErr bool
+	}{
+		{"int", new(int), "12", false},
+		{"int not a number", new(int), "x", true},
+		{"int8 overflow", new(int8), "128", true},
+		{"uint negative", new(uint), "-1", true},
+		{"uint16 overflow", new(uint16), "70000", true},
+		{"float", new(float64), "1.5", false},
+		{"float not a number", new(float64), "x", true},
+		{"bool", new(bool), "true", false},
+		{"bool not a word", new(bool), "maybe", true},
+		{"string", new(string), "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.ValueOf(tt.target).Elem()
+			err := NewParser().setValue(field, tt.value, "field")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setValue(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if err != nil && !field.IsZero() {
+				t.Errorf("setValue(%q) set %v on error", tt.value, field)
+			}
+		})
+	}
+}
+
+func TestUnterminatedKeysDoNotShadowFields(t *testing.T) {
+	type form struct {
+		ID   int               `form:"id"`
+		Meta map[string]string `form:"meta"`
+		Tags []string          `form:"tags"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		want     form
+	}{
+		{"scalar with unterminated key", "id=5&id[id=7", form{ID: 5}},
+		{"unterminated key alone", "id[id=7", form{}},
+		{"map with unterminated key", "meta[a]=x&meta[b=y", form{Meta: map[string]string{"a": "x"}}},
+		{"slice with unterminated key", "tags[0]=a&tags[1=b", form{Tags: []string{"a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser().ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindFieldDataSkipsUnterminatedKeys(t *testing.T) {
+	values := url.Values{"a": {"1"}, "a[b": {"2"}, "a[c]": {"3"}}
+	got := NewParser().findFieldData(values, "a")
+	want := map[string]string{"a": "1", "c]": "3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findFieldData() = %v, want %v", got, want)
+	}
+}
+
+func TestNestedInterfaceMaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		formData string
+		want     map[string]interface{}
+	}{
+		{
+			name:     "nested maps",
+			formData: "meta[a][b]=1&meta[a][c][d]=true",
+			want:     map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": map[string]interface{}{"d": true}}},
+		},
+		{
+			name:     "slices of scalars and maps",
+			formData: "meta[tags][0]=x&meta[tags][2][k]=v",
+			want:     map[string]interface{}{"tags": []interface{}{"x", nil, map[string]interface{}{"k": "v"}}},
+		},
+		{
+			name:     "slices of slices",
+			formData: "meta[grid][0][1]=5&meta[grid][1][0]=6",
+			want:     map[string]interface{}{"grid": []interface{}{[]interface{}{nil, 5}, []interface{}{6}}},
+		},
+		{
+			name:     "mixed indexed and named children",
+			formData: "meta[mix][0]=a&meta[mix][n]=b&meta[mix][1][k]=c",
+			want:     map[string]interface{}{"mix": map[string]interface{}{"0": "a", "n": "b", "1": map[string]interface{}{"k": "c"}}},
+		},
+		{
+			name:     "own value beside nested keys",
+			formData: "meta[a]=1&meta[a][b]=2",
+			want:     map[string]interface{}{"a": map[string]interface{}{"value": 1, "b": 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Meta map[string]interface{} `form:"meta"`
+			}
+			parser := NewParser()
+			if err := parser.ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Meta, tt.want) {
+				t.Errorf("ParseForm() Meta = %#v, want %#v", got.Meta, tt.want)
+			}
+
+			m, err := parser.FormToMap(tt.formData)
+			if err != nil {
+				t.Fatalf("FormToMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(m["meta"], interface{}(tt.want)) {
+				t.Errorf("FormToMap() meta = %#v, want %#v", m["meta"], tt.want)
+			}
+		})
+	}
+}
+
+func TestPresenceBools(t *testing.T) {
+	type prefs struct {
+		Email bool `form:"email" presence:"true"`
+	}
+	type form struct {
+		Agree  bool  `form:"agree" presence:"true"`
+		Plain  bool  `form:"plain"`
+		Nested prefs `form:"prefs"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		initial  form
+		want     form
+	}{
+		{"present with a value", "agree=on&prefs[email]=1", form{}, form{Agree: true, Nested: prefs{Email: true}}},
+		{"present but empty", "agree=&prefs[email]=", form{}, form{Agree: true, Nested: prefs{Email: true}}},
+		{"present with a false word", "agree=false", form{}, form{Agree: true}},
+		{"absent clears the field", "plain=true&prefs[other]=x", form{Agree: true, Nested: prefs{Email: true}}, form{Plain: true}},
+		{"plain bools keep their value when absent", "", form{Plain: true}, form{Plain: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.initial
+			if err := NewParser(WithStrictMode()).ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNarrowIntegerOverflow(t *testing.T) {
+	type form struct {
+		I8    int8   `form:"i8"`
+		I16   int16  `form:"i16"`
+		Hex8  int8   `form:"hex8,format=hex"`
+		Oct8  int8   `form:"oct8,format=octal"`
+		U8    uint8  `form:"u8"`
+		U16   uint16 `form:"u16"`
+		UHex8 uint8  `form:"uhex8,format=hex"`
+		UOct8 uint8  `form:"uoct8,format=octal"`
+	}
+
+	tests := []struct {
+		name      string
+		formData  string
+		want      form
+		wantField string
+	}{
+		{"int8 in range", "i8=-128", form{I8: -128}, ""},
+		{"int8 decimal overflow", "i8=300", form{}, "i8"},
+		{"int8 decimal underflow", "i8=-129", form{}, "i8"},
+		{"int16 decimal overflow", "i16=32768", form{}, "i16"},
+		{"int8 hex in range", "hex8=7f", form{Hex8: 127}, ""},
+		{"int8 hex overflow", "hex8=80", form{}, "hex8"},
+		{"int8 octal in range", "oct8=177", form{Oct8: 127}, ""},
+		{"int8 octal overflow", "oct8=777", form{}, "oct8"},
+		{"uint8 in range", "u8=255", form{U8: 255}, ""},
+		{"uint8 decimal overflow", "u8=256", form{}, "u8"},
+		{"uint16 decimal overflow", "u16=65536", form{}, "u16"},
+		{"uint8 hex in range", "uhex8=ff", form{UHex8: 255}, ""},
+		{"uint8 hex overflow", "uhex8=1ff", form{}, "uhex8"},
+		{"uint8 prefixed hex overflow", "uhex8=0x1ff", form{}, "uhex8"},
+		{"uint8 octal in range", "uoct8=377", form{UOct8: 255}, ""},
+		{"uint8 octal overflow", "uoct8=777", form{}, "uoct8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser().ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+
+			var strict form
+			err := NewParser(WithStrictMode()).ParseForm(tt.formData, &strict)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("strict ParseForm() error = %v", err)
+				}
+				return
+			}
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 || errs[0].Field != tt.wantField || !errors.Is(errs[0], ErrInvalidValue) {
+				t.Errorf("strict ParseForm() error = %v, want ErrInvalidValue for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestStrictModeReportsOverflow(t *testing.T) {
+	type form struct {
+		I8  int8      `form:"i8"`
+		U8  uint8     `form:"u8"`
+		F32 float32   `form:"f"`
+		S   []int8    `form:"s"`
+		M   []float32 `form:"m"`
+	}
+
+	tests := []struct {
+		name      string
+		formData  string
+		wantField string
+	}{
+		{"int8", "i8=300", "i8"},
+		{"uint8", "u8=300", "u8"},
+		{"float32", "f=1e300", "f"},
+		{"negative float32", "f=-1e300", "f"},
+		{"int8 slice element", "s[0]=300", "s[0]"},
+		{"float32 slice element", "m[0]=1e300", "m[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(WithStrictMode()).ParseForm(tt.formData, &got)
+			if !errors.Is(err, ErrInvalidValue) {
+				t.Fatalf("ParseForm() error = %v, want ErrInvalidValue", err)
+			}
+			if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Field != tt.wantField {
+				t.Errorf("ParseForm() error = %v, want one error for %s", err, tt.wantField)
+			}
+			if got.I8 != 0 || got.U8 != 0 || got.F32 != 0 {
+				t.Errorf("ParseForm() = %+v, want the overflowing field left zero", got)
+			}
+		})
+	}
+}
+
+func TestPointerFields(t *testing.T) {
+	type address struct {
+		City string `form:"city"`
+	}
+	type form struct {
+		Work *address `form:"work"`
+		Age  *int     `form:"age"`
+		Note *string  `form:"note"`
+		Home struct {
+			Prev *address `form:"prev"`
+		} `form:"home"`
+	}
+
+	var got form
+	if err := NewParser().ParseForm("work[city]=Bergen&age=30&home[prev][city]=Oslo", &got); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if got.Work == nil || got.Work.City != "Bergen" {
+		t.Errorf("Work = %+v, want Bergen", got.Work)
+	}
+	if got.Age == nil || *got.Age != 30 {
+		t.Errorf("Age = %v, want 30", got.Age)
+	}
+	if got.Note != nil {
+		t.Errorf("Note = %v, want nil without data", got.Note)
+	}
+	if got.Home.Prev == nil || got.Home.Prev.City != "Oslo" {
+		t.Errorf("Home.Prev = %+v, want Oslo", got.Home.Prev)
+	}
+
+	err := NewParser(WithStrictMode()).ParseForm("age=abc", &got)
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 1 || errs[0].Field != "age" {
+		t.Errorf("ParseForm() error = %v, want an age error", err)
+	}
+}
+
+func TestParseFormFromJSONMatchesForm(t *testing.T) {
+	type item struct {
+		SKU string `form:"sku"`
+		Qty int    `form:"qty"`
+	}
+	type order struct {
+		User struct {
+			Name string `form:"name"`
+		} `form:"user"`
+		Items []item   `form:"items"`
+		Tags  []string `form:"tags"`
+		Note  string   `form:"note"`
+		Paid  bool     `form:"paid"`
+	}
+
+	tests := []struct {
+		name     string
+		json     string
+		formData string
+	}{
+		{"nested object", `{"user":{"name":"Alice"}}`, "user[name]=Alice"},
+		{"array of objects", `{"items":[{"sku":"a","qty":2},{"sku":"b","qty":3}]}`, "items[0][sku]=a&items[0][qty]=2&items[1][sku]=b&items[1][qty]=3"},
+		{"array of scalars", `{"tags":["x","y"],"paid":true}`, "tags[0]=x&tags[1]=y&paid=true"},
+		{"null", `{"user":{"name":"Alice"},"note":null}`, "user[name]=Alice"},
+		{"null inside array", `{"items":[{"sku":"a","qty":null}]}`, "items[0][sku]=a"},
+	}
+
+	parser := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromJSON, fromForm order
+			if err := parser.ParseFormFromJSON([]byte(tt.json), &fromJSON); err != nil {
+				t.Fatalf("ParseFormFromJSON() error = %v", err)
+			}
+			if err := parser.ParseForm(tt.formData, &fromForm); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(fromJSON, fromForm) {
+				t.Errorf("ParseFormFromJSON() = %+v, ParseForm() = %+v", fromJSON, fromForm)
+			}
+		})
+	}
+}
+
+// A JSON number out of a field's range fails to convert like any form value: the
+// field is silently left zero, and only strict mode reports it.
+func TestParseFormFromJSONOutOfRangeNumber(t *testing.T) {
+	type form struct {
+		Float float64 `form:"float"`
+		Int   int     `form:"int"`
+	}
+	body := []byte(`{"float":1e400,"int":1e400}`)
+
+	var got form
+	if err := NewParser().ParseFormFromJSON(body, &got); err != nil {
+		t.Fatalf("ParseFormFromJSON() error = %v, want nil", err)
+	}
+	if got != (form{}) {
+		t.Errorf("ParseFormFromJSON() = %+v, want zero fields", got)
+	}
+
+	err := NewParser(WithStrictMode()).ParseFormFromJSON(body, &got)
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("strict ParseFormFromJSON() error = %v, want ErrInvalidValue", err)
+	}
+	if errs, ok := err.(ValidationErrors); !ok || len(errs) != 2 {
+		t.Errorf("strict ParseFormFromJSON() error = %v, want errors for both fields", err)
+	}
+}
@@ -0,0 +1,68 @@
+package parseform
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Pipeline preprocesses form data before it is parsed into a struct.
+// Steps run in the order they were added.
+type Pipeline struct {
+	parser   *Parser
+	formData string
+	err      error
+}
+
+// ParseFormPipeline starts a pipeline over the given form data
+func (p *Parser) ParseFormPipeline(formData string) *Pipeline {
+	return &Pipeline{parser: p, formData: formData}
+}
+
+// Transform replaces the raw form data with the result of fn
+func (pl *Pipeline) Transform(fn func(string) string) *Pipeline {
+	if pl.err == nil {
+		pl.formData = fn(pl.formData)
+	}
+	return pl
+}
+
+// Filter keeps only the pairs for which fn returns true. The key and value
+// are passed unescaped; kept pairs retain their original encoding and order.
+func (pl *Pipeline) Filter(fn func(key, val string) bool) *Pipeline {
+	if pl.err != nil {
+		return pl
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(pl.formData, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			pl.err = fmt.Errorf("failed to parse form data: %w", err)
+			return pl
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			pl.err = fmt.Errorf("failed to parse form data: %w", err)
+			return pl
+		}
+		if fn(key, value) {
+			kept = append(kept, pair)
+		}
+	}
+
+	pl.formData = strings.Join(kept, "&")
+	return pl
+}
+
+// Into parses the processed form data into target
+func (pl *Pipeline) Into(target interface{}) error {
+	if pl.err != nil {
+		return pl.err
+	}
+	return pl.parser.ParseForm(pl.formData, target)
+}
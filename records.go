@@ -0,0 +1,79 @@
+package parseform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ParseRecords splits data on sep, such as "\x1e" (ASCII record separator), parses
+// each record as a form and appends it to the slice targetSlicePtr points to. The
+// slice may hold structs or pointers to structs. Empty and whitespace-only records
+// are skipped; parsing stops at the first record that fails.
+func (p *Parser) ParseRecords(data, sep string, targetSlicePtr interface{}) error {
+	if sep == "" {
+		return errors.New("record separator must not be empty")
+	}
+
+	sliceValue := reflect.ValueOf(targetSlicePtr)
+	if sliceValue.Kind() != reflect.Ptr || sliceValue.IsNil() || sliceValue.Elem().Kind() != reflect.Slice {
+		return errors.New("target must be a pointer to a slice")
+	}
+	slice := sliceValue.Elem()
+
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("slice elements must be structs or pointers to structs, got %s", elemType)
+	}
+
+	for i, record := range strings.Split(data, sep) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+
+		elem := reflect.New(structType)
+		if err := p.ParseForm(strings.TrimSpace(record), elem.Interface()); err != nil {
+			return fmt.Errorf("failed to parse record %d: %w", i, err)
+		}
+
+		if elemType.Kind() == reflect.Ptr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+
+	return nil
+}
+
+// ParseFormTable parses spreadsheet-style form data, where each row is addressed as
+// row[i][column], with the parser's options and appends the rows to the slice
+// targetSlicePtr points to, in index order. Columns map to the element type's fields
+// by their form tags, so row[0][name]=Alice&row[1][name]=Bob yields two rows, and
+// missing indices produce zero-valued rows.
+func (p *Parser) ParseFormTable(formData string, targetSlicePtr interface{}) error {
+	sliceValue := reflect.ValueOf(targetSlicePtr)
+	if sliceValue.Kind() != reflect.Ptr || sliceValue.IsNil() || sliceValue.Elem().Kind() != reflect.Slice {
+		return errors.New("target must be a pointer to a slice")
+	}
+	slice := sliceValue.Elem()
+
+	// Rows are parsed through a struct holding them under the row key
+	tableType := reflect.StructOf([]reflect.StructField{{
+		Name: "Rows",
+		Type: slice.Type(),
+		Tag:  `form:"row"`,
+	}})
+	table := reflect.New(tableType)
+	if err := p.ParseForm(formData, table.Interface()); err != nil {
+		return err
+	}
+
+	slice.Set(reflect.AppendSlice(slice, table.Elem().Field(0)))
+	return nil
+}
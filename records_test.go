@@ -0,0 +1,60 @@
+package parseform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFormTable(t *testing.T) {
+	type person struct {
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+
+	t.Run("appends rows in index order", func(t *testing.T) {
+		people := []person{{Name: "Existing"}}
+		if err := ParseFormTable("row[1][name]=Bob&row[1][age]=25&row[0][name]=Alice", &people); err != nil {
+			t.Fatalf("ParseFormTable() error = %v", err)
+		}
+		want := []person{{Name: "Existing"}, {Name: "Alice"}, {Name: "Bob", Age: 25}}
+		if !reflect.DeepEqual(people, want) {
+			t.Errorf("ParseFormTable() = %+v, want %+v", people, want)
+		}
+	})
+
+	t.Run("uses the parser's options", func(t *testing.T) {
+		var people []person
+		err := NewParser(WithStrictMode()).ParseFormTable("row[0][name]=Alice&row[0][age]=x", &people)
+		errs, ok := err.(ValidationErrors)
+		if !ok || len(errs) != 1 || errs[0].Field != "row[0][age]" {
+			t.Fatalf("ParseFormTable() error = %v, want a row[0][age] error", err)
+		}
+
+		var lenient []person
+		if err := NewParser(WithLenientMode()).ParseFormTable("row[0][age]=3.7", &lenient); err != nil {
+			t.Fatalf("ParseFormTable() error = %v", err)
+		}
+		if want := []person{{Age: 3}}; !reflect.DeepEqual(lenient, want) {
+			t.Errorf("ParseFormTable() = %+v, want %+v", lenient, want)
+		}
+	})
+
+	t.Run("pointer rows", func(t *testing.T) {
+		var people []*person
+		if err := NewParser().ParseFormTable("row[0][name]=Alice", &people); err != nil {
+			t.Fatalf("ParseFormTable() error = %v", err)
+		}
+		if len(people) != 1 || people[0] == nil || people[0].Name != "Alice" {
+			t.Errorf("ParseFormTable() = %+v, want one row for Alice", people)
+		}
+	})
+
+	t.Run("rejects targets that are not slice pointers", func(t *testing.T) {
+		var people []person
+		for _, target := range []interface{}{people, (*[]person)(nil), new(person)} {
+			if err := NewParser().ParseFormTable("row[0][name]=Alice", target); err == nil {
+				t.Errorf("ParseFormTable(%T) error = nil, want an error", target)
+			}
+		}
+	})
+}
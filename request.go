@@ -0,0 +1,159 @@
+package parseform
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultMaxBodySize caps request bodies and decompressed form data
+const defaultMaxBodySize = 10 << 20
+
+// defaultReadBufferSize is the chunk size ParseFormFromReader reads by default
+const defaultReadBufferSize = 4096
+
+// ParseRequest parses the form-urlencoded body of an HTTP request into a struct.
+// Bodies sent with Content-Encoding gzip or deflate are decompressed first.
+// The body, before and after decompression, is limited to the parser's maximum body size.
+func (p *Parser) ParseRequest(r *http.Request, target interface{}) error {
+	if r.Body == nil {
+		return p.ParseForm("", target)
+	}
+
+	data, err := p.readLimited(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	return p.ParseCompressed(data, r.Header.Get("Content-Encoding"), target)
+}
+
+// ParseCompressed decompresses form data with the given content encoding
+// ("gzip", "deflate" or "identity") and parses it into a struct.
+// The decompressed size is capped to guard against zip-bomb amplification.
+func (p *Parser) ParseCompressed(data []byte, encoding string, target interface{}) error {
+	var reader io.Reader
+	switch encoding = strings.ToLower(strings.TrimSpace(encoding)); encoding {
+	case "", "identity":
+		return p.ParseFormBytes(data, target)
+
+	case "gzip", "x-gzip":
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to decompress form data: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+
+	case "deflate":
+		// HTTP deflate is usually zlib-wrapped, but some clients send raw deflate
+		zlibReader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			reader = flate.NewReader(bytes.NewReader(data))
+		} else {
+			defer zlibReader.Close()
+			reader = zlibReader
+		}
+
+	default:
+		return fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	decompressed, err := p.readLimited(reader)
+	if err != nil {
+		return fmt.Errorf("failed to decompress form data: %w", err)
+	}
+
+	return p.ParseFormBytes(decompressed, target)
+}
+
+// ParseFormFromReader parses form-urlencoded data read from r into a struct, reading
+// it in chunks of bufSize bytes, or 4096 when bufSize is not positive. Each pair is
+// decoded once its terminating & arrives, so a pair split across chunks is joined
+// first; larger chunks mean fewer reads for bulk imports. The decoded values are all
+// kept until the struct is filled, and the total read is limited to the parser's
+// maximum body size.
+func (p *Parser) ParseFormFromReader(r io.Reader, bufSize int, target interface{}) error {
+	if bufSize <= 0 {
+		bufSize = defaultReadBufferSize
+	}
+	limit := p.bodyLimit()
+	limited := &io.LimitedReader{R: r, N: limit + 1}
+	scanner := bufio.NewScanner(limited)
+	scanner.Buffer(make([]byte, 0, bufSize), int(limit))
+	scanner.Split(scanFormPairs)
+
+	session := *p
+	values := make(url.Values)
+	if p.keyedSlices {
+		session.keyOrder = make(map[string]int)
+	}
+	for i := 0; scanner.Scan(); i++ {
+		if limited.N <= 0 {
+			return fmt.Errorf("form data exceeds %d bytes", limit)
+		}
+		pair := scanner.Text()
+		pairValues, err := url.ParseQuery(pair)
+		if err != nil {
+			return fmt.Errorf("failed to parse form data: %w", err)
+		}
+		for key, value := range pairValues {
+			values[key] = append(values[key], value...)
+		}
+		if session.keyOrder != nil {
+			for key := range session.formKeyOrder(pair) {
+				if _, seen := session.keyOrder[key]; !seen {
+					session.keyOrder[key] = i
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read form data: %w", err)
+	}
+	if limited.N <= 0 {
+		return fmt.Errorf("form data exceeds %d bytes", limit)
+	}
+
+	return session.parseIntoStruct(values, target)
+}
+
+// scanFormPairs is a bufio.SplitFunc that splits form data into its &-separated pairs
+func scanFormPairs(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, '&'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// bodyLimit returns the maximum body size, defaulting to defaultMaxBodySize
+func (p *Parser) bodyLimit() int64 {
+	if p.maxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return p.maxBodySize
+}
+
+// readLimited reads r fully, failing once more than the maximum body size is read
+func (p *Parser) readLimited(r io.Reader) ([]byte, error) {
+	limit := p.bodyLimit()
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("form data exceeds %d bytes", limit)
+	}
+
+	return data, nil
+}
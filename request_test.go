@@ -0,0 +1,125 @@
+package parseform
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParseFormFromReaderPairsAcrossChunks(t *testing.T) {
+	type form struct {
+		Name string   `form:"name"`
+		Bio  string   `form:"bio"`
+		Tags []string `form:"tags"`
+	}
+	bio := strings.Repeat("x", 40)
+	formData := "name=John%20Smith&bio=" + bio + "&tags[0]=a&tags[1]=b"
+	want := form{Name: "John Smith", Bio: bio, Tags: []string{"a", "b"}}
+
+	tests := []struct {
+		name    string
+		reader  func() io.Reader
+		bufSize int
+	}{
+		{"one byte reads", func() io.Reader { return iotest.OneByteReader(strings.NewReader(formData)) }, 1},
+		{"escape split by the chunk", func() io.Reader { return strings.NewReader(formData) }, 9},
+		{"pair longer than the chunk", func() io.Reader { return strings.NewReader(formData) }, 16},
+		{"half reads", func() io.Reader { return iotest.HalfReader(strings.NewReader(formData)) }, 8},
+		{"default chunk", func() io.Reader { return strings.NewReader(formData) }, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser().ParseFormFromReader(tt.reader(), tt.bufSize, &got); err != nil {
+				t.Fatalf("ParseFormFromReader() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseFormFromReader() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseFormFromReaderLimit(t *testing.T) {
+	var got struct {
+		Bio string `form:"bio"`
+	}
+	formData := "bio=" + strings.Repeat("x", 100)
+	if err := NewParser(WithMaxBodySize(50)).ParseFormFromReader(strings.NewReader(formData), 8, &got); err == nil {
+		t.Error("ParseFormFromReader() error = nil, want a size limit error")
+	}
+}
+
+func compressed(t *testing.T, s string, newWriter func(io.Writer) io.WriteCloser) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := newWriter(&buf)
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseCompressed(t *testing.T) {
+	type form struct {
+		Name string `form:"name"`
+		Bio  string `form:"bio"`
+	}
+	const formData = "name=Ann&bio=hello"
+	gzipWriter := func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }
+	zlibWriter := func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }
+	flateWriter := func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	}
+	large := "name=Ann&bio=" + strings.Repeat("x", 1000)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+		opts     []Option
+		wantErr  string
+	}{
+		{"identity", []byte(formData), "identity", nil, ""},
+		{"no encoding", []byte(formData), "", nil, ""},
+		{"gzip", compressed(t, formData, gzipWriter), "gzip", nil, ""},
+		{"x-gzip in upper case", compressed(t, formData, gzipWriter), " X-GZIP ", nil, ""},
+		{"zlib deflate", compressed(t, formData, zlibWriter), "deflate", nil, ""},
+		{"raw deflate", compressed(t, formData, flateWriter), "deflate", nil, ""},
+		{"within limit", compressed(t, formData, gzipWriter), "gzip", []Option{WithMaxBodySize(int64(len(formData)))}, ""},
+		{"gzip over limit", compressed(t, large, gzipWriter), "gzip", []Option{WithMaxBodySize(100)}, "exceeds 100 bytes"},
+		{"deflate over limit", compressed(t, large, zlibWriter), "deflate", []Option{WithMaxBodySize(100)}, "exceeds 100 bytes"},
+		{"corrupt gzip", []byte(formData), "gzip", nil, "failed to decompress"},
+		{"unsupported encoding", []byte(formData), "br", nil, "unsupported content encoding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(tt.opts...).ParseCompressed(tt.data, tt.encoding, &got)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseCompressed() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCompressed() error = %v", err)
+			}
+			if got != (form{Name: "Ann", Bio: "hello"}) {
+				t.Errorf("ParseCompressed() = %+v, want Ann hello", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package parseform
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// SchemaRegistry maps names to struct types so the target of a parse can be
+// chosen at runtime, for example from a webhook's event type
+type SchemaRegistry struct {
+	parser  *Parser
+	mu      sync.RWMutex
+	schemas map[string]reflect.Type
+}
+
+// NewSchemaRegistry creates a registry that parses with the given parser, or with
+// a default parser when it is nil
+func NewSchemaRegistry(parser *Parser) *SchemaRegistry {
+	if parser == nil {
+		parser = NewParser()
+	}
+	return &SchemaRegistry{parser: parser, schemas: make(map[string]reflect.Type)}
+}
+
+// RegisterSchema stores the type of v, a struct or pointer to struct, under name.
+// Registering a name again replaces its schema. It panics if v is not a struct.
+func (r *SchemaRegistry) RegisterSchema(name string, v interface{}) {
+	schemaType := reflect.TypeOf(v)
+	if schemaType != nil && schemaType.Kind() == reflect.Ptr {
+		schemaType = schemaType.Elem()
+	}
+	if schemaType == nil || schemaType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("parseform: schema %q must be a struct, got %T", name, v))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schemas[name] = schemaType
+}
+
+// ParseFormBySchema allocates a new value of the schema registered under name and
+// parses formData into it, returning a pointer to the struct
+func (r *SchemaRegistry) ParseFormBySchema(name, formData string) (interface{}, error) {
+	r.mu.RLock()
+	schemaType, registered := r.schemas[name]
+	r.mu.RUnlock()
+	if !registered {
+		return nil, fmt.Errorf("schema %q is not registered", name)
+	}
+
+	target := reflect.New(schemaType)
+	if err := r.parser.ParseForm(formData, target.Interface()); err != nil {
+		return target.Interface(), err
+	}
+	return target.Interface(), nil
+}
+
+// ParseFormVersioned reads the version key of formData, like version=2, and parses
+// formData into a new value of the struct registered for that version, returning a
+// pointer to it. Registry values are samples of the struct or pointer to struct, as
+// in map[string]interface{}{"1": PayloadV1{}, "2": PayloadV2{}}. A missing version
+// or one without a registered struct is an error.
+func (p *Parser) ParseFormVersioned(formData string, registry map[string]interface{}) (interface{}, error) {
+	version, found := p.Get(formData, "version")
+	if !found {
+		return nil, fmt.Errorf("form data has no version")
+	}
+
+	sample, registered := registry[version]
+	if !registered {
+		return nil, fmt.Errorf("version %q is not registered", version)
+	}
+	schemaType := reflect.TypeOf(sample)
+	if schemaType != nil && schemaType.Kind() == reflect.Ptr {
+		schemaType = schemaType.Elem()
+	}
+	if schemaType == nil || schemaType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("version %q must be registered with a struct, got %T", version, sample)
+	}
+
+	target := reflect.New(schemaType)
+	if err := p.ParseForm(formData, target.Interface()); err != nil {
+		return target.Interface(), err
+	}
+	return target.Interface(), nil
+}
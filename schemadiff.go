@@ -0,0 +1,136 @@
+package parseform
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaChange classifies a difference between two form schemas
+type SchemaChange string
+
+const (
+	// SchemaFieldRemoved marks a form field present in the first schema only
+	SchemaFieldRemoved SchemaChange = "removed"
+	// SchemaFieldAdded marks a form field present in the second schema only
+	SchemaFieldAdded SchemaChange = "added"
+	// SchemaTypeChanged marks a form field whose Go type differs
+	SchemaTypeChanged SchemaChange = "type changed"
+	// SchemaOptionsChanged marks a form field whose form tag options differ
+	SchemaOptionsChanged SchemaChange = "options changed"
+)
+
+// SchemaDiff describes one difference between two form schemas. Field is the form
+// key in bracket notation, like "user[address][city]"; Old and New hold the type or
+// tag options on each side, empty when the field is absent.
+type SchemaDiff struct {
+	Field  string
+	Change SchemaChange
+	Old    string
+	New    string
+}
+
+// schemaField is a form field found while walking a schema. Fields holding nested
+// structs compare by shape, like "[]struct", since their fields are compared separately.
+type schemaField struct {
+	typ     reflect.Type
+	shape   string
+	options string
+}
+
+// DiffSchemas compares the form fields of two struct types, given as values or
+// pointers, and lists fields removed from a, added in b, and fields whose type or
+// form tag options changed. Nested structs and slices of structs are compared field
+// by field, with slice elements written as items[]. Differences are ordered by field.
+func DiffSchemas(a, b interface{}) []SchemaDiff {
+	oldFields := collectSchemaFields(reflect.TypeOf(a))
+	newFields := collectSchemaFields(reflect.TypeOf(b))
+
+	var diffs []SchemaDiff
+	for key, oldField := range oldFields {
+		newField, exists := newFields[key]
+		switch {
+		case !exists:
+			diffs = append(diffs, SchemaDiff{Field: key, Change: SchemaFieldRemoved, Old: oldField.typ.String()})
+		case oldField.shape != newField.shape:
+			diffs = append(diffs, SchemaDiff{Field: key, Change: SchemaTypeChanged, Old: oldField.typ.String(), New: newField.typ.String()})
+		case oldField.options != newField.options:
+			diffs = append(diffs, SchemaDiff{Field: key, Change: SchemaOptionsChanged, Old: oldField.options, New: newField.options})
+		}
+	}
+	for key, newField := range newFields {
+		if _, exists := oldFields[key]; !exists {
+			diffs = append(diffs, SchemaDiff{Field: key, Change: SchemaFieldAdded, New: newField.typ.String()})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs
+}
+
+// collectSchemaFields lists the form fields of a struct type by their bracket paths
+func collectSchemaFields(t reflect.Type) map[string]schemaField {
+	fields := make(map[string]schemaField)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fields
+	}
+
+	walkSchemaFields(&Parser{}, t, "", fields, map[reflect.Type]bool{})
+	return fields
+}
+
+// walkSchemaFields records the fields of structType under prefix, descending into
+// nested structs. seen guards against recursive types.
+func walkSchemaFields(p *Parser, structType reflect.Type, prefix string, fields map[string]schemaField, seen map[reflect.Type]bool) {
+	if seen[structType] {
+		return
+	}
+	seen[structType] = true
+	defer delete(seen, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+		tag, skip := p.parseFormTag(structType, fieldType)
+		if skip {
+			continue
+		}
+
+		key := tag.name
+		if prefix != "" {
+			key = prefix + "[" + tag.name + "]"
+		}
+
+		options := append([]string(nil), tag.options...)
+		sort.Strings(options)
+		field := schemaField{typ: fieldType.Type, shape: fieldType.Type.String(), options: strings.Join(options, ",")}
+
+		// Descend into nested structs, directly or as slice, array or pointer elements
+		elemType := fieldType.Type
+		elemKey := key
+		container := ""
+		for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array {
+			switch elemType.Kind() {
+			case reflect.Ptr:
+				container += "*"
+			case reflect.Slice:
+				container += "[]"
+				elemKey += "[]"
+			default:
+				container += "[" + strconv.Itoa(elemType.Len()) + "]"
+				elemKey += "[]"
+			}
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() == reflect.Struct && elemType != timeType {
+			field.shape = container + "struct"
+			walkSchemaFields(p, elemType, elemKey, fields, seen)
+		}
+		fields[key] = field
+	}
+}
@@ -0,0 +1,95 @@
+package parseform
+
+import (
+	"reflect"
+	"testing"
+)
+
+type diffAddressV1 struct {
+	City string `form:"city"`
+	Zip  int    `form:"zip"`
+}
+
+type diffAddressV2 struct {
+	City   string `form:"city"`
+	Zip    string `form:"zip"`
+	Street string `form:"street"`
+}
+
+type diffItem struct {
+	SKU string `form:"sku"`
+}
+
+type diffNode struct {
+	Name     string     `form:"name"`
+	Children []diffNode `form:"children"`
+}
+
+type diffFormV1 struct {
+	Name    string        `form:"name"`
+	Age     int           `form:"age"`
+	Email   string        `form:"email,readonly"`
+	Legacy  string        `form:"legacy"`
+	Address diffAddressV1 `form:"address"`
+	Items   []diffItem    `form:"items"`
+	Tree    diffNode      `form:"tree"`
+	Skipped string        `form:"-"`
+}
+
+type diffFormV2 struct {
+	Name    string        `form:"name"`
+	Age     string        `form:"age"`
+	Email   string        `form:"email,positive,readonly"`
+	Phone   string        `form:"phone"`
+	Address diffAddressV2 `form:"address"`
+	Items   diffItem      `form:"items"`
+	Tree    diffNode      `form:"tree"`
+}
+
+func TestDiffSchemas(t *testing.T) {
+	want := []SchemaDiff{
+		{Field: "address[street]", Change: SchemaFieldAdded, New: "string"},
+		{Field: "address[zip]", Change: SchemaTypeChanged, Old: "int", New: "string"},
+		{Field: "age", Change: SchemaTypeChanged, Old: "int", New: "string"},
+		{Field: "email", Change: SchemaOptionsChanged, Old: "readonly", New: "positive,readonly"},
+		{Field: "items", Change: SchemaTypeChanged, Old: "[]parseform.diffItem", New: "parseform.diffItem"},
+		{Field: "items[][sku]", Change: SchemaFieldRemoved, Old: "string"},
+		{Field: "items[sku]", Change: SchemaFieldAdded, New: "string"},
+		{Field: "legacy", Change: SchemaFieldRemoved, Old: "string"},
+		{Field: "phone", Change: SchemaFieldAdded, New: "string"},
+	}
+
+	got := DiffSchemas(diffFormV1{}, &diffFormV2{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffSchemas() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDiffSchemasUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+	}{
+		{"same type", diffFormV1{}, diffFormV1{}},
+		{"value and pointer", diffFormV1{}, &diffFormV1{}},
+		{"recursive type", diffNode{}, diffNode{}},
+		{"nested structs of different types but equal fields", struct {
+			A diffAddressV1 `form:"a"`
+		}{}, struct {
+			A struct {
+				City string `form:"city"`
+				Zip  int    `form:"zip"`
+			} `form:"a"`
+		}{}},
+		{"non-structs", 1, "x"},
+		{"nil", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffSchemas(tt.a, tt.b); len(got) != 0 {
+				t.Errorf("DiffSchemas() = %+v, want no differences", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package parseform
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StepResult is the outcome of a ParseStep
+type StepResult string
+
+const (
+	// StepSet means the field was assigned from the form
+	StepSet StepResult = "set"
+	// StepSkipped means the field was left untouched, for example because the form
+	// has no data for it or its value could not be converted
+	StepSkipped StepResult = "skipped"
+	// StepError means the field produced one or more field errors
+	StepError StepResult = "error"
+)
+
+// ParseStep records what happened to one struct field during a parse
+type ParseStep struct {
+	// Key is the field's form key in bracket notation, like "user[profile][city]"
+	Key string
+	// Field is the Go field, qualified by its struct type name, like "Profile.City"
+	Field string
+	// Value is the raw form value for a single-valued field, or empty
+	Value string
+	// Result is the field's outcome
+	Result StepResult
+	// Detail explains a skipped or failed field
+	Detail string
+}
+
+// ParseTrace lists the steps of a parse, one per struct field at any depth, in the
+// order the fields finished parsing, so nested fields come before their parent
+type ParseTrace struct {
+	Steps []ParseStep
+}
+
+// ParseFormWithDebug parses form data into a struct like ParseForm and also returns a
+// trace of what happened to every field, in place of printf debugging
+func (p *Parser) ParseFormWithDebug(formData string, target interface{}) (*ParseTrace, error) {
+	trace := &ParseTrace{}
+	session := *p
+	session.trace = trace
+	err := session.parseFormData(formData, target)
+	return trace, err
+}
+
+// traceMark remembers the error and failure counts before a field is parsed
+type traceMark struct {
+	errs, typeErrs, failures int
+}
+
+// markTrace records the counts a field's trace step is measured against
+func (p *Parser) markTrace(errCount int) traceMark {
+	return traceMark{errs: errCount, typeErrs: p.typeErrorCount(), failures: len(p.failedPaths)}
+}
+
+// traceField adds the outcome of a field to the trace of ParseFormWithDebug
+func (p *Parser) traceField(mark traceMark, structType reflect.Type, fieldType reflect.StructField, tag formTag, path string, fieldData map[string]string, errs ValidationErrors) {
+	if p.trace == nil {
+		return
+	}
+
+	step := ParseStep{Key: path, Field: fieldType.Name, Value: fieldData[tag.name], Result: StepSet}
+	if structType.Name() != "" {
+		step.Field = structType.Name() + "." + fieldType.Name
+	}
+
+	// Errors and failures of nested fields belong to their own steps
+	var messages []string
+	fieldErrs := errs[mark.errs:]
+	if p.typeErrors != nil {
+		fieldErrs = append(fieldErrs[:len(fieldErrs):len(fieldErrs)], (*p.typeErrors)[mark.typeErrs:]...)
+	}
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Field == path {
+			messages = append(messages, fieldErr.Message)
+		}
+	}
+	failed := false
+	for _, failedPath := range p.failedPaths[mark.failures:] {
+		failed = failed || failedPath == path
+	}
+
+	switch {
+	case len(messages) > 0:
+		step.Result, step.Detail = StepError, strings.Join(messages, "; ")
+	case tag.hasOption("readonly") && fieldData != nil:
+		step.Result, step.Detail = StepSkipped, "read-only"
+	case fieldData == nil && tag.presence():
+		step.Detail = "absent checkbox"
+	case fieldData == nil:
+		step.Result, step.Detail = StepSkipped, "no form data"
+	case failed:
+		step.Result, step.Detail = StepSkipped, "value could not be converted"
+	}
+	p.trace.Steps = append(p.trace.Steps, step)
+}
@@ -0,0 +1,35 @@
+package parseform
+
+import (
+	"fmt"
+	"strings"
+)
+
+// transformFieldData pipes each value of a field's data through the transforms
+// named in its transform tag, in order, returning fieldData unchanged when the
+// field has none
+func (p *Parser) transformFieldData(fieldData map[string]string, tag formTag, path string) (map[string]string, error) {
+	names := tag.structTag.Get("transform")
+	if names == "" {
+		return fieldData, nil
+	}
+
+	var transforms []func(string) string
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		fn, registered := p.transforms[name]
+		if !registered {
+			return nil, fmt.Errorf("unknown transform %q on %s", name, path)
+		}
+		transforms = append(transforms, fn)
+	}
+
+	transformed := make(map[string]string, len(fieldData))
+	for key, value := range fieldData {
+		for _, fn := range transforms {
+			value = fn(value)
+		}
+		transformed[key] = value
+	}
+	return transformed, nil
+}
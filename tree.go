@@ -0,0 +1,114 @@
+package parseform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// TreeNodeKind identifies what a TreeNode holds
+type TreeNodeKind int
+
+const (
+	// TreeScalar is a single form value
+	TreeScalar TreeNodeKind = iota
+	// TreeObject is a set of named children, like user[name] and user[email]
+	TreeObject
+	// TreeArray is a list of indexed items, like items[0] and items[1]
+	TreeArray
+)
+
+// String returns the kind's name
+func (k TreeNodeKind) String() string {
+	switch k {
+	case TreeScalar:
+		return "scalar"
+	case TreeObject:
+		return "object"
+	case TreeArray:
+		return "array"
+	}
+	return "TreeNodeKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// TreeNode is a node of the tree FormToTree builds. Scalars keep both the raw form
+// value and the value FormToMap would infer from it, so consumers can choose their
+// own coercion.
+type TreeNode struct {
+	Kind TreeNodeKind
+	// Raw is a scalar's value exactly as it appeared in the form
+	Raw string
+	// Value is a scalar's inferred value: an int, int64, float64, bool or string
+	Value interface{}
+	// Children holds an object's members by key
+	Children map[string]*TreeNode
+	// Items holds an array's elements by index; indices missing from the form are nil
+	Items []*TreeNode
+}
+
+// FormToTree converts form data into a tree that marks every node as a scalar,
+// object or array. It is the structure behind FormToMap, made explicit: a node
+// that has both its own value and nested keys, like a=1&a[b]=2, becomes an object
+// with the value under "value".
+func (p *Parser) FormToTree(formData string) (*TreeNode, error) {
+	values, err := url.ParseQuery(formData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse form data: %w", err)
+	}
+	if p.jsonPointerKeys {
+		values = p.rewriteKeys(values, p.jsonPointerKey)
+	}
+	if err := p.checkRepeatedKeys(values); err != nil {
+		return nil, err
+	}
+
+	root := &TreeNode{Kind: TreeObject, Children: make(map[string]*TreeNode)}
+	for baseKey, group := range p.groupKeysByStructure(p.expandEmptyBrackets(values)) {
+		if node := p.treeFromGroup(group); node != nil {
+			root.Children[baseKey] = node
+		}
+	}
+	return root, nil
+}
+
+// treeFromGroup converts a key group into a tree node, or nil when it holds no data
+func (p *Parser) treeFromGroup(group *keyGroup) *TreeNode {
+	hasNested := len(group.children) > 0 || len(group.arrayData) > 0
+	switch {
+	case group.isSimple && !hasNested:
+		return &TreeNode{Kind: TreeScalar, Raw: group.raw, Value: group.value}
+
+	case !hasNested:
+		return nil
+
+	case !group.isSimple && len(group.children) == 0:
+		maxIndex := 0
+		for index := range group.arrayData {
+			if index > maxIndex {
+				maxIndex = index
+			}
+		}
+
+		node := &TreeNode{Kind: TreeArray, Items: make([]*TreeNode, maxIndex+1)}
+		for index, item := range group.arrayData {
+			node.Items[index] = p.treeFromGroup(item)
+		}
+		return node
+	}
+
+	node := &TreeNode{Kind: TreeObject, Children: make(map[string]*TreeNode)}
+	if group.isSimple {
+		node.Children["value"] = &TreeNode{Kind: TreeScalar, Raw: group.raw, Value: group.value}
+	}
+	for key, child := range group.children {
+		if childNode := p.treeFromGroup(child); childNode != nil {
+			node.Children[key] = childNode
+		}
+	}
+	for index, child := range group.arrayData {
+		if childNode := p.treeFromGroup(child); childNode != nil {
+			node.Children[strconv.Itoa(index)] = childNode
+		}
+	}
+	return node
+}
@@ -0,0 +1,74 @@
+package parseform
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormToTreeValuesMatchFormToMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		formData string
+		opts     []Option
+	}{
+		{"top-level scalars", "b=true&n=25&f=1.5&s=bob", nil},
+		{"nested scalars", "user[b]=true&user[n]=25&list[0]=1.5&list[1]=x", nil},
+		{"mixed indexed and named keys", "a[0]=x&a[n]=y&b=1&b[c]=2&d[0][e]=3&d[0][1]=4", nil},
+		{"custom bool words", "flag=yes&x[flag]=no&list[0]=yes", []Option{WithBoolValues([]string{"yes"}, []string{"no"})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser(tt.opts...)
+			tree, err := parser.FormToTree(tt.formData)
+			if err != nil {
+				t.Fatalf("FormToTree() error = %v", err)
+			}
+			m, err := parser.FormToMap(tt.formData)
+			if err != nil {
+				t.Fatalf("FormToMap() error = %v", err)
+			}
+
+			if got := treeToInterface(tree); !reflect.DeepEqual(got, interface{}(m)) {
+				t.Errorf("FormToTree() values = %#v, FormToMap() = %#v", got, m)
+			}
+		})
+	}
+}
+
+func TestFormToTreeKeepsRaw(t *testing.T) {
+	tree, err := NewParser().FormToTree("n=007&user[b]=TRUE")
+	if err != nil {
+		t.Fatalf("FormToTree() error = %v", err)
+	}
+
+	n := tree.Children["n"]
+	if n.Kind != TreeScalar || n.Raw != "007" || n.Value != 7 {
+		t.Errorf("n = %+v, want raw 007 and value 7", n)
+	}
+	b := tree.Children["user"].Children["b"]
+	if b.Kind != TreeScalar || b.Raw != "TRUE" || b.Value != true {
+		t.Errorf("user[b] = %+v, want raw TRUE and value true", b)
+	}
+}
+
+// treeToInterface flattens a tree into the shape FormToMap returns
+func treeToInterface(node *TreeNode) interface{} {
+	switch node.Kind {
+	case TreeScalar:
+		return node.Value
+	case TreeArray:
+		items := make([]interface{}, len(node.Items))
+		for i, item := range node.Items {
+			if item != nil {
+				items[i] = treeToInterface(item)
+			}
+		}
+		return items
+	}
+	children := make(map[string]interface{}, len(node.Children))
+	for key, child := range node.Children {
+		children[key] = treeToInterface(child)
+	}
+	return children
+}
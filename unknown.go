@@ -0,0 +1,91 @@
+package parseform
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// unknownKeys reports, under WithUnknownNestedKeysError, every key that no field of
+// structType reads, at any depth, by its full bracket path
+func (p *Parser) unknownKeys(values url.Values, structType reflect.Type) ValidationErrors {
+	var errs ValidationErrors
+	for key := range values {
+		if !p.knownKey(structType, key) {
+			errs = append(errs, FieldError{Field: key, Message: "is not a known field", Err: ErrUnknownKey})
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// knownKey reports whether a key in bracket form, relative to a struct of type
+// structType like "profile[city]", reaches one of the struct's fields
+func (p *Parser) knownKey(structType reflect.Type, key string) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		fieldType := structType.Field(i)
+		tag, skip := p.parseFormTag(structType, fieldType)
+		if skip {
+			continue
+		}
+		if tag.hasOption("raw") {
+			return true
+		}
+
+		for _, joined := range tag.joinedKeys() {
+			if key == joined {
+				return true
+			}
+		}
+		if sources, err := p.zipSources(tag, tag.name); err == nil && tag.structTag.Get("zip") != "" {
+			for _, source := range sources {
+				if key == source.key {
+					return true
+				}
+			}
+		}
+		if key == tag.name {
+			return true
+		}
+		if rest, nested := strings.CutPrefix(key, tag.name+"["); nested && p.knownNestedKey(fieldType.Type, tag, "["+rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownNestedKey reports whether the bracket remainder of a key, like "[0][name]",
+// reaches a value within a field of type t
+func (p *Parser) knownNestedKey(t reflect.Type, tag formTag, remainder string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Single values, interfaces and encoded values take whatever is below them
+	if remainder == "" || !p.isCompositeType(t) || t.Kind() == reflect.Interface ||
+		tag.structTag.Get("nested") == "json" || tag.hasOption("xml") {
+		return true
+	}
+
+	segment, rest, ok := p.splitKeySegment(remainder[1:])
+	if !ok || !strings.HasPrefix(remainder, "[") {
+		return false
+	}
+	if rest != "" {
+		rest = "[" + rest
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return p.knownKey(t, segment+rest)
+	case reflect.Slice, reflect.Array:
+		if _, isIndex := p.arrayIndex(segment); !isIndex && !p.keyedSlices {
+			return false
+		}
+		return p.knownNestedKey(t.Elem(), formTag{}, rest)
+	case reflect.Map:
+		return p.knownNestedKey(t.Elem(), formTag{}, rest)
+	}
+	return false
+}
@@ -0,0 +1,398 @@
+package parseform
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// oneofGroups tracks the members of each oneof group declared on a struct and which of them were present
+type oneofGroups struct {
+	names   []string
+	members map[string][]string
+	present map[string][]string
+}
+
+// add records a member of a oneof group
+func (g *oneofGroups) add(group, fieldKey string, present bool) {
+	if g.members == nil {
+		g.members = make(map[string][]string)
+		g.present = make(map[string][]string)
+	}
+
+	if _, seen := g.members[group]; !seen {
+		g.names = append(g.names, group)
+	}
+	g.members[group] = append(g.members[group], fieldKey)
+	if present {
+		g.present[group] = append(g.present[group], fieldKey)
+	}
+}
+
+// validate reports every group that does not have exactly one member present
+func (g *oneofGroups) validate() ValidationErrors {
+	var errs ValidationErrors
+	for _, group := range g.names {
+		present := g.present[group]
+		if len(present) == 1 {
+			continue
+		}
+
+		got := "none"
+		if len(present) > 1 {
+			got = strings.Join(present, ", ")
+		}
+		errs = append(errs, FieldError{
+			Field:   group,
+			Message: fmt.Sprintf("requires exactly one of %s, got %s", strings.Join(g.members[group], ", "), got),
+			Err:     ErrOneOf,
+		})
+	}
+	return errs
+}
+
+// fieldError reports a problem with a field, using its msg tag or errmsg option in
+// place of the default message when one is set
+func (p *Parser) fieldError(tag formTag, path, message string, err error) FieldError {
+	if msg := tag.message(); msg != "" {
+		message = msg
+	}
+	return FieldError{Field: path, Message: message, Err: err}
+}
+
+// applyFieldMessage replaces the messages of errors reported for the field itself with
+// its msg tag or errmsg option, leaving errors of nested fields and elements untouched
+func (p *Parser) applyFieldMessage(errs ValidationErrors, tag formTag, path string) {
+	msg := tag.message()
+	if msg == "" {
+		return
+	}
+	for i := range errs {
+		if errs[i].Field == path {
+			errs[i].Message = msg
+		}
+	}
+}
+
+// patternCache holds compiled pattern tags by pattern string
+var patternCache sync.Map
+
+// validateField checks a parsed field value against the constraints in its tag
+// options, like form:"amount,positive", its range and length tags and its pattern
+// tag. An invalid bound or pattern is returned as an error rather than a field error.
+func (p *Parser) validateField(field reflect.Value, tag formTag, path string) (ValidationErrors, error) {
+	var errs ValidationErrors
+
+	if isNumericKind(field.Kind()) {
+		sign := numericSign(field)
+		if tag.hasOption("positive") && sign <= 0 {
+			errs = append(errs, p.fieldError(tag, path, "must be positive", ErrConstraint))
+		}
+		if tag.hasOption("negative") && sign >= 0 {
+			errs = append(errs, p.fieldError(tag, path, "must be negative", ErrConstraint))
+		}
+		for _, bound := range numericBounds {
+			limit, ok := tag.structTag.Lookup(bound.tag)
+			if !ok {
+				continue
+			}
+			cmp, err := compareNumeric(field, limit)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s bound on %s: %w", bound.tag, path, err)
+			}
+			if !bound.allows(cmp) {
+				errs = append(errs, p.fieldError(tag, path, bound.message+" "+limit, ErrConstraint))
+			}
+		}
+	}
+
+	if kind := field.Kind(); kind == reflect.String || kind == reflect.Slice {
+		length, unit := field.Len(), "elements"
+		if kind == reflect.String {
+			length, unit = p.stringLength(field.String())
+		}
+		for _, bound := range lengthBounds {
+			limit, ok := tag.structTag.Lookup(bound.tag)
+			if !ok {
+				continue
+			}
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid %s on %s: %q", bound.tag, path, limit)
+			}
+			if !bound.allows(compareOrdered(int64(length), int64(n))) {
+				errs = append(errs, p.fieldError(tag, path, fmt.Sprintf("%s %d %s", bound.message, n, unit), ErrConstraint))
+			}
+		}
+	}
+
+	if pattern := tag.structTag.Get("pattern"); pattern != "" && field.Kind() == reflect.String {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern on %s: %w", path, err)
+		}
+		if !re.MatchString(field.String()) {
+			errs = append(errs, p.fieldError(tag, path, "does not match pattern "+pattern, ErrConstraint))
+		}
+	}
+
+	return errs, nil
+}
+
+// numericBounds are the range tags of numeric fields. allows reports whether a value
+// comparing to the bound as cmp (-1, 0 or 1) is in range.
+var numericBounds = []struct {
+	tag     string
+	message string
+	allows  func(cmp int) bool
+}{
+	{"min", "must be at least", func(cmp int) bool { return cmp >= 0 }},
+	{"minExclusive", "must be greater than", func(cmp int) bool { return cmp > 0 }},
+	{"max", "must be at most", func(cmp int) bool { return cmp <= 0 }},
+	{"maxExclusive", "must be less than", func(cmp int) bool { return cmp < 0 }},
+}
+
+// lengthBounds are the length tags of string and slice fields
+var lengthBounds = []struct {
+	tag     string
+	message string
+	allows  func(cmp int) bool
+}{
+	{"minLen", "must have at least", func(cmp int) bool { return cmp >= 0 }},
+	{"maxLen", "must have at most", func(cmp int) bool { return cmp <= 0 }},
+}
+
+// stringLength returns the length of a string field's value for the length tags, in
+// runes, or in bytes under WithByteLength, along with the unit's name
+func (p *Parser) stringLength(value string) (int, string) {
+	if p.byteLength {
+		return len(value), "bytes"
+	}
+	return utf8.RuneCountInString(value), "characters"
+}
+
+// compareNumeric compares a numeric field's value with a bound from a tag, returning
+// -1, 0 or 1. Integer fields are compared exactly when the bound is an integer.
+func compareNumeric(field reflect.Value, bound string) (int, error) {
+	limit, err := strconv.ParseFloat(bound, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	var value float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if exact, err := strconv.ParseInt(bound, 10, 64); err == nil {
+			return compareOrdered(field.Int(), exact), nil
+		}
+		value = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if exact, err := strconv.ParseUint(bound, 10, 64); err == nil {
+			return compareOrdered(field.Uint(), exact), nil
+		}
+		value = float64(field.Uint())
+	default:
+		value = field.Float()
+	}
+	return compareOrdered(value, limit), nil
+}
+
+// compareOrdered returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compilePattern compiles a pattern tag, reusing earlier compilations of the same pattern
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := patternCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.Store(pattern, re)
+	return re, nil
+}
+
+// numericSign returns -1, 0 or 1 for the sign of a numeric field's value
+func numericSign(field reflect.Value) int {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := field.Int(); {
+		case v < 0:
+			return -1
+		case v > 0:
+			return 1
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > 0 {
+			return 1
+		}
+	case reflect.Float32, reflect.Float64:
+		switch v := field.Float(); {
+		case v < 0:
+			return -1
+		case v > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// ignoreErrors removes the errors in errs[start:] matched by WithIgnoreErrors and
+// returns the removed ones
+func (p *Parser) ignoreErrors(errs *ValidationErrors, start int) ValidationErrors {
+	if len(p.ignoredErrors) == 0 {
+		return nil
+	}
+
+	var removed ValidationErrors
+	kept := (*errs)[:start]
+	for _, fieldErr := range (*errs)[start:] {
+		if p.isIgnored(fieldErr) {
+			removed = append(removed, fieldErr)
+		} else {
+			kept = append(kept, fieldErr)
+		}
+	}
+
+	*errs = kept
+	return removed
+}
+
+// zeroIgnored resets what each ignored error of the field at path was reported for:
+// the whole field for its own errors, or only the failing element, entry or nested
+// field for errors below it, so valid siblings are kept
+func (p *Parser) zeroIgnored(field reflect.Value, path string, ignored ValidationErrors) {
+	for _, fieldErr := range ignored {
+		rest, below := strings.CutPrefix(fieldErr.Field, path)
+		if !below {
+			continue
+		}
+		p.zeroPath(field, rest)
+	}
+}
+
+// zeroPath sets the value reached from v by the bracket segments of rest, like
+// "[2][name]", to its zero value. Map entries on the way are deleted instead, as
+// they cannot be set in place, and paths that lead nowhere are ignored.
+func (p *Parser) zeroPath(v reflect.Value, rest string) {
+	if rest != "" && !strings.HasPrefix(rest, "[") {
+		return
+	}
+	for key := strings.TrimPrefix(rest, "["); key != ""; {
+		segment, next, ok := p.splitKeySegment(key)
+		if !ok {
+			return
+		}
+		key = next
+
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			next, found := p.fieldByFormKey(v, segment)
+			if !found {
+				return
+			}
+			v = next
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= v.Len() {
+				return
+			}
+			v = v.Index(index)
+		case reflect.Map:
+			key := reflect.New(v.Type().Key()).Elem()
+			if p.setValue(key, segment, "") == nil && !v.IsNil() {
+				v.SetMapIndex(key, reflect.Value{})
+			}
+			return
+		default:
+			return
+		}
+	}
+
+	if v.CanSet() {
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+// isIgnored reports whether err matches one of the errors passed to WithIgnoreErrors
+func (p *Parser) isIgnored(err error) bool {
+	for _, target := range p.ignoredErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredIfFields records missing fields tagged requiredIf, checked once every
+// sibling field has been parsed
+type requiredIfFields []requiredIfField
+
+// requiredIfField is a missing field with a requiredIf condition
+type requiredIfField struct {
+	tag  formTag
+	path string
+}
+
+// add records a missing conditional field
+func (c *requiredIfFields) add(tag formTag, path string) {
+	*c = append(*c, requiredIfField{tag: tag, path: path})
+}
+
+// checkRequiredIf reports each missing field whose requiredIf:"key=value" condition
+// holds: the sibling field with form key "key" has the parsed value "value", compared
+// in its default formatting, so status_id=143 matches an int 143 and active=true a
+// bool. A malformed condition or an unknown sibling key is reported as an error.
+func (p *Parser) checkRequiredIf(structValue reflect.Value, fields requiredIfFields) (ValidationErrors, error) {
+	var errs ValidationErrors
+	for _, conditional := range fields {
+		condition := conditional.tag.structTag.Get("requiredIf")
+		key, want, ok := strings.Cut(condition, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid requiredIf condition %q on %s, want key=value", condition, conditional.path)
+		}
+
+		sibling, found := p.fieldByFormKey(structValue, key)
+		if !found {
+			return nil, fmt.Errorf("requiredIf condition on %s refers to unknown field %s", conditional.path, key)
+		}
+
+		if fmt.Sprint(sibling.Interface()) == want {
+			message := fmt.Sprintf("is required when %s is %s", key, want)
+			errs = append(errs, p.fieldError(conditional.tag, conditional.path, message, ErrRequired))
+		}
+	}
+	return errs, nil
+}
+
+// fieldByFormKey finds the field of a struct with the given form key
+func (p *Parser) fieldByFormKey(structValue reflect.Value, key string) (reflect.Value, bool) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		if tag, skip := p.parseFormTag(structType, structType.Field(i)); !skip && tag.name == key {
+			return structValue.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
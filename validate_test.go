@@ -0,0 +1,343 @@
+package parseform
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIgnoreErrorsKeepsValidElements(t *testing.T) {
+	type item struct {
+		ID   int    `form:"id,readonly"`
+		Name string `form:"name"`
+	}
+	type user struct {
+		ID   int    `form:"id,readonly"`
+		Name string `form:"name"`
+		Age  int    `form:"age"`
+	}
+	type form struct {
+		Fixed  [2]string `form:"fixed"`
+		Capped []string  `form:"capped"`
+		Items  []item    `form:"items"`
+		Pairs  [2]item   `form:"pairs"`
+		User   user      `form:"user"`
+		Nums   []int     `form:"nums"`
+		Triple [3]int    `form:"triple"`
+		Amount int       `form:"amount,positive"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		opts     []Option
+		want     form
+	}{
+		{
+			name:     "index overflow in array",
+			formData: "fixed[0]=a&fixed[1]=b&fixed[5]=c",
+			opts:     []Option{WithIndexOverflowPolicy(IndexOverflowError), WithIgnoreErrors(ErrIndexOverflow)},
+			want:     form{Fixed: [2]string{"a", "b"}},
+		},
+		{
+			name:     "index overflow in capped slice",
+			formData: "capped[0]=a&capped[1]=b&capped[2]=c",
+			opts:     []Option{WithMaxSliceLength(2), WithIndexOverflowPolicy(IndexOverflowError), WithIgnoreErrors(ErrIndexOverflow)},
+			want:     form{Capped: []string{"a", "b"}},
+		},
+		{
+			name:     "read-only field in slice elements",
+			formData: "items[0][id]=1&items[0][name]=x&items[1][name]=y",
+			opts:     []Option{WithIgnoreErrors(ErrReadOnly)},
+			want:     form{Items: []item{{Name: "x"}, {Name: "y"}}},
+		},
+		{
+			name:     "read-only field in array elements",
+			formData: "pairs[0][name]=x&pairs[1][id]=2&pairs[1][name]=y",
+			opts:     []Option{WithIgnoreErrors(ErrReadOnly)},
+			want:     form{Pairs: [2]item{{Name: "x"}, {Name: "y"}}},
+		},
+		{
+			name:     "read-only field in nested struct",
+			formData: "user[id]=1&user[name]=x&user[age]=30",
+			opts:     []Option{WithIgnoreErrors(ErrReadOnly)},
+			want:     form{User: user{Name: "x", Age: 30}},
+		},
+		{
+			name:     "invalid value in slice",
+			formData: "nums[0]=1&nums[1]=abc&nums[2]=3",
+			opts:     []Option{WithStrictMode(), WithIgnoreErrors(ErrInvalidValue)},
+			want:     form{Nums: []int{1, 0, 3}},
+		},
+		{
+			name:     "invalid value in array",
+			formData: "triple[0]=1&triple[1]=abc&triple[2]=3",
+			opts:     []Option{WithStrictMode(), WithIgnoreErrors(ErrInvalidValue)},
+			want:     form{Triple: [3]int{1, 0, 3}},
+		},
+		{
+			name:     "invalid value in nested struct",
+			formData: "user[name]=x&user[age]=abc",
+			opts:     []Option{WithStrictMode(), WithIgnoreErrors(ErrInvalidValue)},
+			want:     form{User: user{Name: "x"}},
+		},
+		{
+			name:     "field's own error zeroes the field",
+			formData: "amount=-5&fixed[0]=a",
+			opts:     []Option{WithIgnoreErrors(ErrConstraint)},
+			want:     form{Fixed: [2]string{"a", ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			if err := NewParser(tt.opts...).ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseForm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreErrorsReportsOtherErrors(t *testing.T) {
+	var got struct {
+		Fixed [2]string `form:"fixed"`
+		Age   int       `form:"age"`
+	}
+	parser := NewParser(WithStrictMode(), WithIndexOverflowPolicy(IndexOverflowError), WithIgnoreErrors(ErrIndexOverflow))
+	err := parser.ParseForm("fixed[0]=a&fixed[3]=b&age=abc", &got)
+
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Field != "age" {
+		t.Fatalf("ParseForm() error = %v, want only the age error", err)
+	}
+	if got.Fixed != [2]string{"a", ""} {
+		t.Errorf("Fixed = %v, want [a ]", got.Fixed)
+	}
+}
+
+func TestPatternTag(t *testing.T) {
+	type form struct {
+		Code string `form:"code" pattern:"^[A-Z]{3}-[0-9]{2}$"`
+		Slug string `form:"slug" pattern:"[a-z]+"`
+	}
+
+	tests := []struct {
+		name      string
+		formData  string
+		wantField string
+	}{
+		{"matching value", "code=ABC-12", ""},
+		{"value not matching", "code=abc-12", "code"},
+		{"anchored pattern rejects extra text", "code=ABC-123", "code"},
+		{"unanchored pattern matches a substring", "slug=Hello-world", ""},
+		{"unanchored pattern without a match", "slug=123", "slug"},
+		{"absent field is not checked", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser().ParseForm(tt.formData, &got)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ParseForm() error = %v, want nil", err)
+				}
+				return
+			}
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 || errs[0].Field != tt.wantField || !errors.Is(err, ErrConstraint) {
+				t.Errorf("ParseForm() error = %v, want an ErrConstraint for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestPatternTagInvalidRegex(t *testing.T) {
+	var got struct {
+		Code string `form:"code" pattern:"[A-Z"`
+	}
+	for i := 0; i < 2; i++ {
+		err := NewParser().ParseForm("code=ABC", &got)
+		if err == nil || !strings.Contains(err.Error(), "invalid pattern on code") {
+			t.Fatalf("ParseForm() error = %v, want an invalid pattern error", err)
+		}
+		if errors.Is(err, ErrConstraint) {
+			t.Errorf("ParseForm() error = %v, want a setup error, not a field error", err)
+		}
+	}
+	if _, ok := patternCache.Load("[A-Z"); ok {
+		t.Error("invalid pattern was cached")
+	}
+}
+
+func TestCompilePatternReusesCompilation(t *testing.T) {
+	first, err := compilePattern("^cache-[0-9]+$")
+	if err != nil {
+		t.Fatalf("compilePattern() error = %v", err)
+	}
+	second, err := compilePattern("^cache-[0-9]+$")
+	if err != nil {
+		t.Fatalf("compilePattern() error = %v", err)
+	}
+	if first != second {
+		t.Error("compilePattern() compiled the same pattern twice")
+	}
+}
+
+func TestRangeTags(t *testing.T) {
+	type form struct {
+		Int      int     `form:"int" min:"1" max:"10"`
+		IntEx    int8    `form:"int_ex" minExclusive:"-5" maxExclusive:"5"`
+		IntFrac  int     `form:"int_frac" min:"1.5"`
+		Big      int64   `form:"big" max:"9007199254740993"`
+		Uint     uint    `form:"uint" min:"2" max:"4"`
+		UintEx   uint16  `form:"uint_ex" minExclusive:"0" maxExclusive:"65535"`
+		Float    float64 `form:"float" min:"0.5" max:"1.5"`
+		FloatEx  float32 `form:"float_ex" minExclusive:"0" maxExclusive:"1"`
+		Negative int     `form:"negative" min:"-10" max:"-1"`
+	}
+
+	tests := []struct {
+		name      string
+		formData  string
+		wantField string
+	}{
+		{"int at min", "int=1", ""},
+		{"int at max", "int=10", ""},
+		{"int below min", "int=0", "int"},
+		{"int above max", "int=11", "int"},
+		{"int just inside exclusive min", "int_ex=-4", ""},
+		{"int just inside exclusive max", "int_ex=4", ""},
+		{"int at exclusive min", "int_ex=-5", "int_ex"},
+		{"int at exclusive max", "int_ex=5", "int_ex"},
+		{"int below fractional min", "int_frac=1", "int_frac"},
+		{"int above fractional min", "int_frac=2", ""},
+		{"int64 at max beyond float precision", "big=9007199254740993", ""},
+		{"int64 above max beyond float precision", "big=9007199254740994", "big"},
+		{"negative at min", "negative=-10", ""},
+		{"negative at max", "negative=-1", ""},
+		{"negative above max", "negative=0", "negative"},
+		{"uint at min", "uint=2", ""},
+		{"uint at max", "uint=4", ""},
+		{"uint below min", "uint=1", "uint"},
+		{"uint above max", "uint=5", "uint"},
+		{"uint at exclusive min", "uint_ex=0", "uint_ex"},
+		{"uint just inside exclusive min", "uint_ex=1", ""},
+		{"uint just inside exclusive max", "uint_ex=65534", ""},
+		{"uint at exclusive max", "uint_ex=65535", "uint_ex"},
+		{"float at min", "float=0.5", ""},
+		{"float at max", "float=1.5", ""},
+		{"float below min", "float=0.49", "float"},
+		{"float above max", "float=1.51", "float"},
+		{"float at exclusive min", "float_ex=0", "float_ex"},
+		{"float at exclusive max", "float_ex=1", "float_ex"},
+		{"float inside exclusive bounds", "float_ex=0.001", ""},
+		{"absent fields are not checked", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser().ParseForm(tt.formData, &got)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ParseForm() error = %v, want nil", err)
+				}
+				return
+			}
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 || errs[0].Field != tt.wantField || !errors.Is(err, ErrConstraint) {
+				t.Errorf("ParseForm() error = %v, want an ErrConstraint for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestRangeTagInvalidBound(t *testing.T) {
+	var got struct {
+		Age int `form:"age" min:"ten"`
+	}
+	err := NewParser().ParseForm("age=5", &got)
+	if err == nil || !strings.Contains(err.Error(), "invalid min bound on age") {
+		t.Errorf("ParseForm() error = %v, want an invalid bound error", err)
+	}
+}
+
+func TestLengthTags(t *testing.T) {
+	type form struct {
+		Name string   `form:"name" minLen:"2" maxLen:"4"`
+		Tags []string `form:"tags" minLen:"1" maxLen:"2"`
+	}
+
+	tests := []struct {
+		name      string
+		formData  string
+		opts      []Option
+		wantField string
+	}{
+		{"string at min", "name=ab", nil, ""},
+		{"string at max", "name=abcd", nil, ""},
+		{"string below min", "name=a", nil, "name"},
+		{"string above max", "name=abcde", nil, "name"},
+		{"empty string", "name=", nil, "name"},
+		{"multibyte string counted in runes", "name=%C3%A9t%C3%A9s", nil, ""},
+		{"multibyte string over max in runes", "name=%E6%97%A5%E6%9C%AC%E8%AA%9E%E3%81%A7%E3%81%99", nil, "name"},
+		{"multibyte string counted in bytes", "name=%C3%A9t%C3%A9s", []Option{WithByteLength()}, "name"},
+		{"single rune of two bytes", "name=%C3%A9", nil, "name"},
+		{"single rune of two bytes in bytes", "name=%C3%A9", []Option{WithByteLength()}, ""},
+		{"slice at min", "tags[0]=a", nil, ""},
+		{"slice at max", "tags[0]=a&tags[1]=b", nil, ""},
+		{"slice above max", "tags[0]=a&tags[1]=b&tags[2]=c", nil, "tags"},
+		{"slice elements ignore byte length", "tags[0]=%C3%A9%C3%A9%C3%A9", []Option{WithByteLength()}, ""},
+		{"absent fields are not checked", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got form
+			err := NewParser(tt.opts...).ParseForm(tt.formData, &got)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ParseForm() error = %v, want nil", err)
+				}
+				return
+			}
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) != 1 || errs[0].Field != tt.wantField || !errors.Is(err, ErrConstraint) {
+				t.Errorf("ParseForm() error = %v, want an ErrConstraint for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestLengthTagMessages(t *testing.T) {
+	var got struct {
+		Name string   `form:"name" maxLen:"2"`
+		Tags []string `form:"tags" minLen:"2"`
+	}
+
+	tests := []struct {
+		name     string
+		formData string
+		opts     []Option
+		want     string
+	}{
+		{"runes", "name=abc", nil, "must have at most 2 characters"},
+		{"bytes", "name=abc", []Option{WithByteLength()}, "must have at most 2 bytes"},
+		{"elements", "tags[0]=a", nil, "must have at least 2 elements"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewParser(tt.opts...).ParseForm(tt.formData, &got)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseForm() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package parseform
+
+import "errors"
+
+// Validator validates a parsed struct. It matches the Struct method of
+// github.com/go-playground/validator, so a *validator.Validate can be plugged in
+// directly to honor validate:"gte=0,lte=150" tags without this package duplicating them.
+type Validator interface {
+	Struct(s interface{}) error
+}
+
+// ParseFormAndValidate parses form data into target and, once parsing succeeds, runs
+// the validator configured with WithValidator on it. Parse errors are returned without
+// validating; the validator's error is returned as-is.
+func (p *Parser) ParseFormAndValidate(formData string, target interface{}) error {
+	if p.validator == nil {
+		return errors.New("no validator configured, use WithValidator")
+	}
+
+	if err := p.ParseForm(formData, target); err != nil {
+		return err
+	}
+	return p.validator.Struct(target)
+}
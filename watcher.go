@@ -0,0 +1,41 @@
+package parseform
+
+import (
+	"reflect"
+	"sync"
+)
+
+// FormWatcher parses successive submissions of the same form and reports whether
+// each one changes the parsed result, for polling webhooks or debouncing repeated
+// submissions. It is safe for concurrent use.
+type FormWatcher[T any] struct {
+	parser *Parser
+
+	mu     sync.Mutex
+	last   T
+	parsed bool
+}
+
+// NewFormWatcher creates a watcher that parses with a parser built from opts
+func NewFormWatcher[T any](opts ...Option) *FormWatcher[T] {
+	return &FormWatcher[T]{parser: NewParser(opts...)}
+}
+
+// Changed parses formData into a new T and reports whether it differs, by
+// reflect.DeepEqual, from the last successfully parsed value, returning the new
+// value either way. The first successful parse always counts as a change. When
+// parsing fails, the error is returned and the last value is kept.
+func (w *FormWatcher[T]) Changed(formData string) (bool, T, error) {
+	var current T
+	if err := w.parser.ParseForm(formData, &current); err != nil {
+		return false, current, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := !w.parsed || !reflect.DeepEqual(w.last, current)
+	w.last = current
+	w.parsed = true
+	return changed, current, nil
+}
@@ -0,0 +1,75 @@
+package parseform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// zipSource is one parallel array of a zip tag and the element key it fills
+type zipSource struct {
+	key     string
+	elemKey string
+}
+
+// zipSources parses a zip tag like zip:"tag_ids->id,tag_names->name"
+func (p *Parser) zipSources(tag formTag, path string) ([]zipSource, error) {
+	var sources []zipSource
+	for _, pair := range strings.Split(tag.structTag.Get("zip"), ",") {
+		key, elemKey, ok := strings.Cut(pair, "->")
+		key, elemKey = strings.TrimSpace(key), strings.TrimSpace(elemKey)
+		if !ok || key == "" || elemKey == "" {
+			return nil, fmt.Errorf("invalid zip %q on %s: want source->key pairs", pair, path)
+		}
+		sources = append(sources, zipSource{key: key, elemKey: elemKey})
+	}
+	return sources, nil
+}
+
+// zipFieldData builds the element data of a slice field tagged zip from parallel
+// arrays, like tag_ids=5,6,7&tag_names=a,b,c, so element i gets the i-th value of
+// each source under its element key. A source sent once is split on the sep tag, on
+// WithArrayDelimiter, or else on commas; repeated keys give one value each. Sources
+// of different lengths are truncated to the shortest, and the field has no data when
+// none of them is present.
+func (p *Parser) zipFieldData(tag formTag, path string, lookup func(key string) []string) (map[string]string, error) {
+	sources, err := p.zipSources(tag, path)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([][]string, len(sources))
+	length, present := -1, false
+	for i, source := range sources {
+		values := lookup(source.key)
+		if len(values) == 1 {
+			values = p.splitZipValue(values[0], tag)
+		}
+		present = present || len(values) > 0
+		if length < 0 || len(values) < length {
+			length = len(values)
+		}
+		columns[i] = values
+	}
+	if !present {
+		return nil, nil
+	}
+
+	fieldData := make(map[string]string)
+	for row := 0; row < length; row++ {
+		for i, source := range sources {
+			fieldData[strconv.Itoa(row)+"]["+source.elemKey+"]"] = columns[i][row]
+		}
+	}
+	return fieldData, nil
+}
+
+// splitZipValue splits a zip source sent once like splitValue, but on commas when
+// neither a sep tag nor WithArrayDelimiter is set, as parallel arrays are only
+// useful split
+func (p *Parser) splitZipValue(value string, tag formTag) []string {
+	if _, tagged := tag.structTag.Lookup("sep"); !tagged && p.arrayDelimiter == "" {
+		return strings.Split(value, ",")
+	}
+	return p.splitValue(value, tag)
+}
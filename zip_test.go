@@ -0,0 +1,76 @@
+package parseform
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type zipTag struct {
+	ID   int    `form:"id"`
+	Name string `form:"name"`
+}
+
+type zipForm struct {
+	Tags []zipTag `form:"tags" zip:"tag_ids->id,tag_names->name"`
+}
+
+func TestZipParallelArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		formData string
+		opts     []Option
+		want     []zipTag
+	}{
+		{
+			name:     "comma split without sep",
+			formData: "tag_ids=5,6,7&tag_names=a,b,c",
+			want:     []zipTag{{5, "a"}, {6, "b"}, {7, "c"}},
+		},
+		{
+			name:     "mismatched lengths truncate to the shortest",
+			formData: "tag_ids=5,6,7&tag_names=a,b",
+			want:     []zipTag{{5, "a"}, {6, "b"}},
+		},
+		{
+			name:     "repeated keys give one value each",
+			formData: "tag_ids=5&tag_ids=6&tag_names=a&tag_names=b&tag_names=c",
+			want:     []zipTag{{5, "a"}, {6, "b"}},
+		},
+		{
+			name:     "array delimiter replaces the comma",
+			formData: "tag_ids=5|6&tag_names=a,b|c",
+			opts:     []Option{WithArrayDelimiter("|")},
+			want:     []zipTag{{5, "a,b"}, {6, "c"}},
+		},
+		{
+			name:     "missing source gives no elements",
+			formData: "tag_ids=5,6",
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got zipForm
+			if err := NewParser(tt.opts...).ParseForm(tt.formData, &got); err != nil {
+				t.Fatalf("ParseForm() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Tags, tt.want) {
+				t.Errorf("Tags = %+v, want %+v", got.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipReportsConversionFailures(t *testing.T) {
+	var got struct {
+		Tags []zipTag `form:"tags" zip:"tag_ids->id,tag_names->name" sep:";"`
+	}
+	err := NewParser().ParseForm("tag_ids=5,6&tag_names=a,b", &got)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "tags[0][id]" || !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("ParseForm() error = %v, want an ErrInvalidValue for tags[0][id]", err)
+	}
+}